@@ -7,4 +7,7 @@ import (
 var (
 	// ErrMissingAccount is returned if the request keystore account can't be found (via its name)
 	ErrMissingAccount = errors.New("keystore account can't be nil - please make sure the account you want to use exists in the keystore")
+
+	// ErrConfirmationTimeout is returned if a transaction wasn't confirmed before the deadline was reached
+	ErrConfirmationTimeout = errors.New("timed out waiting for the transaction to be confirmed")
 )
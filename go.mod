@@ -8,9 +8,11 @@ require (
 	github.com/btcsuite/btcd v0.21.0-beta
 	github.com/deckarep/golang-set v1.7.1
 	github.com/ethereum/go-ethereum v1.9.23
+	github.com/gorilla/websocket v1.4.2
 	github.com/harmony-one/bls v0.0.7-0.20191214005344-88c23f91a8a9
 	github.com/harmony-one/go-sdk v1.2.2-0.20210123151345-d4635a829001
 	github.com/harmony-one/harmony v1.10.3-0.20210202204804-5643dff467a5
 	github.com/mitchellh/go-homedir v1.1.0
 	github.com/pkg/errors v0.9.1
+	gopkg.in/yaml.v2 v2.3.0
 )
@@ -0,0 +1,36 @@
+package logging
+
+// F - creates a new field
+func F(key string, value interface{}) Field {
+	return Field{Key: key, Value: value}
+}
+
+// TxHash - transaction hash field
+func TxHash(hash string) Field {
+	return F("tx-hash", hash)
+}
+
+// Nonce - nonce field
+func Nonce(nonce uint64) Field {
+	return F("nonce", nonce)
+}
+
+// ShardID - shard id field
+func ShardID(shardID uint32) Field {
+	return F("shard", shardID)
+}
+
+// ToShardID - receiving shard id field
+func ToShardID(shardID uint32) Field {
+	return F("to-shard", shardID)
+}
+
+// Node - node address field
+func Node(node string) Field {
+	return F("node", node)
+}
+
+// Err - error field
+func Err(err error) Field {
+	return F("error", err)
+}
@@ -0,0 +1,131 @@
+package logging
+
+import (
+	"context"
+	"sync"
+
+	"github.com/harmony-one/go-lib/network"
+)
+
+// Level - log level
+type Level int
+
+// Supported log levels
+const (
+	DebugLevel Level = iota
+	InfoLevel
+	WarnLevel
+	ErrorLevel
+)
+
+var levelNames = map[Level]string{
+	DebugLevel: "DEBUG",
+	InfoLevel:  "INFO",
+	WarnLevel:  "WARN",
+	ErrorLevel: "ERROR",
+}
+
+// String - returns the name of the level
+func (level Level) String() string {
+	if name, ok := levelNames[level]; ok {
+		return name
+	}
+
+	return "UNKNOWN"
+}
+
+// Field - a structured key/value pair attached to a log entry
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// Logger - pluggable logger used throughout go-lib, implement it to route go-lib logs into your own output
+type Logger interface {
+	// Log - logs a message at a given level with optional structured fields
+	Log(level Level, message string, fields ...Field)
+	// Enabled - checks if messages at a given level are logged at all, used to skip building expensive log messages
+	Enabled(level Level) bool
+	// With - returns a logger that attaches the given fields to every entry
+	With(fields ...Field) Logger
+}
+
+var (
+	globalMutex  sync.RWMutex
+	globalLogger Logger
+)
+
+type contextKey struct{}
+
+// SetLogger - sets the global logger used whenever no logger has been configured on a Network / call, nil restores the default
+func SetLogger(logger Logger) {
+	globalMutex.Lock()
+	defer globalMutex.Unlock()
+
+	globalLogger = logger
+}
+
+// Global - returns the global logger
+// Unless SetLogger has been called this is a stdout logger at debug level if network.Verbose is enabled and a no-op logger otherwise
+func Global() Logger {
+	globalMutex.RLock()
+	logger := globalLogger
+	globalMutex.RUnlock()
+
+	if logger != nil {
+		return logger
+	}
+
+	if network.Verbose {
+		return defaultVerboseLogger
+	}
+
+	return Noop
+}
+
+// Resolve - returns the first non-nil logger of the given loggers, falls back to the global logger
+func Resolve(loggers ...Logger) Logger {
+	for _, logger := range loggers {
+		if logger != nil {
+			return logger
+		}
+	}
+
+	return Global()
+}
+
+// WithLogger - returns a context carrying a per-call logger
+func WithLogger(ctx context.Context, logger Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, logger)
+}
+
+// FromContext - returns the per-call logger of a given context, falls back to the global logger
+func FromContext(ctx context.Context) Logger {
+	if ctx != nil {
+		if logger, ok := ctx.Value(contextKey{}).(Logger); ok && logger != nil {
+			return logger
+		}
+	}
+
+	return Global()
+}
+
+// Debug - logs a message at debug level
+func Debug(logger Logger, message string, fields ...Field) {
+	Resolve(logger).Log(DebugLevel, message, fields...)
+}
+
+// Info - logs a message at info level
+func Info(logger Logger, message string, fields ...Field) {
+	Resolve(logger).Log(InfoLevel, message, fields...)
+}
+
+// Warn - logs a message at warn level
+func Warn(logger Logger, message string, fields ...Field) {
+	Resolve(logger).Log(WarnLevel, message, fields...)
+}
+
+// Error - logs a message at error level
+func Error(logger Logger, message string, fields ...Field) {
+	Resolve(logger).Log(ErrorLevel, message, fields...)
+}
@@ -0,0 +1,20 @@
+package logging
+
+// Noop - logger discarding all entries
+var Noop Logger = NoopLogger{}
+
+// NoopLogger - logger adapter discarding all entries
+type NoopLogger struct{}
+
+// Log - discards the entry
+func (NoopLogger) Log(level Level, message string, fields ...Field) {}
+
+// Enabled - always false
+func (NoopLogger) Enabled(level Level) bool {
+	return false
+}
+
+// With - returns the no-op logger itself
+func (logger NoopLogger) With(fields ...Field) Logger {
+	return logger
+}
@@ -0,0 +1,66 @@
+package logging
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/harmony-one/go-lib/network"
+)
+
+var defaultVerboseLogger = NewStdLogger(log.New(os.Stdout, "", 0), DebugLevel)
+
+// StdLogger - logger adapter writing to a standard library log.Logger
+// Entries are formatted as "[Harmony SDK]: <time> - <LEVEL> <message> key=value ..."
+type StdLogger struct {
+	Logger *log.Logger
+	Level  Level // Level - minimum level that gets logged
+	Prefix string
+	fields []Field
+}
+
+// NewStdLogger - creates a new standard library logger adapter, a nil log.Logger will log to stdout
+func NewStdLogger(logger *log.Logger, level Level) *StdLogger {
+	if logger == nil {
+		logger = log.New(os.Stdout, "", 0)
+	}
+
+	return &StdLogger{
+		Logger: logger,
+		Level:  level,
+		Prefix: "[Harmony SDK]: ",
+	}
+}
+
+// Log - logs a message at a given level with optional structured fields
+func (logger *StdLogger) Log(level Level, message string, fields ...Field) {
+	if !logger.Enabled(level) {
+		return
+	}
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("%s%s - %s %s", logger.Prefix, time.Now().Format(network.LoggingTimeFormat), level, message))
+
+	for _, field := range append(append([]Field{}, logger.fields...), fields...) {
+		builder.WriteString(fmt.Sprintf(" %s=%v", field.Key, field.Value))
+	}
+
+	logger.Logger.Println(builder.String())
+}
+
+// Enabled - checks if messages at a given level are logged
+func (logger *StdLogger) Enabled(level Level) bool {
+	return level >= logger.Level
+}
+
+// With - returns a logger that attaches the given fields to every entry
+func (logger *StdLogger) With(fields ...Field) Logger {
+	return &StdLogger{
+		Logger: logger.Logger,
+		Level:  logger.Level,
+		Prefix: logger.Prefix,
+		fields: append(append([]Field{}, logger.fields...), fields...),
+	}
+}
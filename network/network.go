@@ -1,7 +1,7 @@
 package network
 
 var (
-	// Verbose - enable or disable verbose output
+	// Verbose - enable or disable verbose output, only used by the default logger (see logging.SetLogger for routing output elsewhere)
 	Verbose = false
 	// LoggingTimeFormat - time format to use for log output
 	LoggingTimeFormat = "2006-01-02 15:04:05"
@@ -1,7 +1,12 @@
 package balances
 
 import (
+	"context"
+	"sort"
+	"sync"
+
 	commonTypes "github.com/harmony-one/go-lib/network/types/common"
+	"github.com/harmony-one/go-lib/rpc"
 	"github.com/harmony-one/go-sdk/pkg/common"
 	goSDK_RPC "github.com/harmony-one/go-sdk/pkg/rpc"
 	"github.com/harmony-one/harmony/common/denominations"
@@ -9,42 +14,158 @@ import (
 	"github.com/pkg/errors"
 )
 
+// ShardBalance - the balance lookup result for a single shard, either Balance or Error is set
+type ShardBalance struct {
+	ShardID uint32
+	Node    string
+	Balance numeric.Dec
+	Error   error
+}
+
+// AddressBalance - the balance lookup result for a single address, either Balance or Error is set
+type AddressBalance struct {
+	Address string
+	Balance numeric.Dec
+	Error   error
+}
+
 // GetAllShardBalances - gets the balances in all shards for a given address
 func GetAllShardBalances(address string, shards map[uint32]string, retry *commonTypes.Retry) (balances map[uint32]numeric.Dec, err error) {
+	return GetAllShardBalancesWithContext(context.Background(), address, shards, retry)
+}
+
+// GetAllShardBalancesWithContext - gets the balances in all shards for a given address using a given context
+// If one or more shards fail the balances for the remaining shards are still returned alongside the first error
+func GetAllShardBalancesWithContext(ctx context.Context, address string, shards map[uint32]string, retry *commonTypes.Retry) (balances map[uint32]numeric.Dec, err error) {
 	balances = make(map[uint32]numeric.Dec)
-	params := []interface{}{address, "latest"}
 
-	for shardID, node := range shards {
-		balanceRPCReply, err := goSDK_RPC.Request(goSDK_RPC.Method.GetBalance, node, params)
-		if err != nil {
-			return nil, errors.Wrapf(err, "rpc.Request")
+	for _, result := range GetShardBalances(ctx, address, shards, retry, 0) {
+		if result.Error != nil {
+			if err == nil {
+				err = errors.Wrapf(result.Error, "rpc.Request")
+			}
+			continue
+		}
+
+		balances[result.ShardID] = result.Balance
+	}
+
+	return balances, err
+}
+
+// GetShardBalances - concurrently gets the balance in every shard for a given address
+// concurrency limits the number of simultaneous requests, 0 or less means one request per shard
+// The results are sorted by shard id and every result carries either a balance or an error
+func GetShardBalances(ctx context.Context, address string, shards map[uint32]string, retry *commonTypes.Retry, concurrency int) []ShardBalance {
+	shardIDs := make([]uint32, 0, len(shards))
+	for shardID := range shards {
+		shardIDs = append(shardIDs, shardID)
+	}
+	sort.Slice(shardIDs, func(i, j int) bool { return shardIDs[i] < shardIDs[j] })
+
+	if concurrency <= 0 || concurrency > len(shardIDs) {
+		concurrency = len(shardIDs)
+	}
+
+	results := make([]ShardBalance, len(shardIDs))
+	jobs := make(chan int)
+	var waitGroup sync.WaitGroup
+
+	for worker := 0; worker < concurrency; worker++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			for index := range jobs {
+				shardID := shardIDs[index]
+				node := shards[shardID]
+				balance, err := getBalance(ctx, rpc.NewClientWithRetry(node, retry), address)
+				results[index] = ShardBalance{ShardID: shardID, Node: node, Balance: balance, Error: err}
+			}
+		}()
+	}
+
+	for index := range shardIDs {
+		jobs <- index
+	}
+	close(jobs)
+	waitGroup.Wait()
+
+	return results
+}
+
+// GetBalancesWithContext - gets the balances for multiple addresses on a single shard using batched requests
+// The returned error is only set if a whole batch failed, errors for individual addresses are reported in their results
+func GetBalancesWithContext(ctx context.Context, client *rpc.Client, addresses []string) ([]AddressBalance, error) {
+	batch := client.NewBatch()
+	for _, address := range addresses {
+		batch.Add(goSDK_RPC.Method.GetBalance, []interface{}{address, "latest"})
+	}
+
+	batchResults, err := batch.Execute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]AddressBalance, len(batchResults))
+	for index, batchResult := range batchResults {
+		results[index] = AddressBalance{Address: addresses[index], Balance: numeric.ZeroDec()}
+
+		var rpcBalance string
+		if err := batchResult.Decode(&rpcBalance); err != nil {
+			results[index].Error = err
+			continue
 		}
 
-		rpcBalance, _ := balanceRPCReply["result"].(string)
-		balance := common.NewDecFromHex(rpcBalance)
-		balance = balance.Quo(numeric.NewDec(denominations.One))
+		results[index].Balance = toOne(rpcBalance)
+	}
+
+	return results, nil
+}
 
-		balances[shardID] = balance
+func getBalance(ctx context.Context, client *rpc.Client, address string) (numeric.Dec, error) {
+	balanceRPCReply, err := client.Request(ctx, goSDK_RPC.Method.GetBalance, []interface{}{address, "latest"})
+	if err != nil {
+		return numeric.ZeroDec(), err
 	}
 
-	return balances, nil
+	rpcBalance, _ := balanceRPCReply["result"].(string)
+
+	return toOne(rpcBalance), nil
+}
+
+func toOne(rpcBalance string) numeric.Dec {
+	balance := common.NewDecFromHex(rpcBalance)
+	return balance.Quo(numeric.NewDec(denominations.One))
 }
 
 // GetShardBalance - gets the balance for a given node, address and shard
 func GetShardBalance(address string, shardID uint32, shards map[uint32]string, retry *commonTypes.Retry) (numeric.Dec, error) {
-	shardBalances, err := GetAllShardBalances(address, shards, retry)
+	return GetShardBalanceWithContext(context.Background(), address, shardID, shards, retry)
+}
+
+// GetShardBalanceWithContext - gets the balance for a given node, address and shard using a given context
+func GetShardBalanceWithContext(ctx context.Context, address string, shardID uint32, shards map[uint32]string, retry *commonTypes.Retry) (numeric.Dec, error) {
+	node, ok := shards[shardID]
+	if !ok {
+		return numeric.ZeroDec(), nil
+	}
+
+	shardBalance, err := getBalance(ctx, rpc.NewClientWithRetry(node, retry), address)
 	if err != nil {
 		return numeric.ZeroDec(), errors.Wrapf(err, "GetShardBalance")
 	}
 
-	shardBalance := shardBalances[shardID]
-
 	return shardBalance, nil
 }
 
 // GetTotalBalance - gets the total balance across all shards for a given node and address
 func GetTotalBalance(address string, shards map[uint32]string, retry *commonTypes.Retry) (numeric.Dec, error) {
-	shardBalances, err := GetAllShardBalances(address, shards, retry)
+	return GetTotalBalanceWithContext(context.Background(), address, shards, retry)
+}
+
+// GetTotalBalanceWithContext - gets the total balance across all shards for a given node and address using a given context
+func GetTotalBalanceWithContext(ctx context.Context, address string, shards map[uint32]string, retry *commonTypes.Retry) (numeric.Dec, error) {
+	shardBalances, err := GetAllShardBalancesWithContext(ctx, address, shards, retry)
 	if err != nil {
 		return numeric.ZeroDec(), errors.Wrapf(err, "GetTotalBalance")
 	}
@@ -1,15 +1,58 @@
 package block
 
 import (
-	"github.com/harmony-one/go-sdk/pkg/rpc"
+	"context"
+	"errors"
+
+	"github.com/harmony-one/go-lib/rpc"
+	goSdkRPC "github.com/harmony-one/go-sdk/pkg/rpc"
 )
 
+// EpochLastBlockMethod - the v2 RPC method returning the last block number of an epoch
+const EpochLastBlockMethod = "hmyv2_epochLastBlock"
+
 // GetCurrentEpoch - returns the block header current epoch
 func GetCurrentEpoch(node string) (uint32, error) {
+	return GetCurrentEpochWithContext(context.Background(), rpc.NewClient(node))
+}
+
+// GetCurrentEpochWithContext - returns the block header current epoch using a given context and client
+func GetCurrentEpochWithContext(ctx context.Context, client *rpc.Client) (uint32, error) {
 	params := []interface{}{}
-	blockReply, err := rpc.Request(rpc.Method.GetLatestBlockHeader, node, params)
+	blockReply, err := client.Request(ctx, goSdkRPC.Method.GetLatestBlockHeader, params)
 	if err != nil {
 		return 0, err
 	}
-	return uint32(blockReply["result"].(map[string]interface{})["epoch"].(float64)), nil
+
+	header, ok := blockReply["result"].(map[string]interface{})
+	if !ok {
+		return 0, errors.New("block header missing from response")
+	}
+
+	epoch, ok := header["epoch"].(float64)
+	if !ok {
+		return 0, errors.New("epoch missing from block header")
+	}
+
+	return uint32(epoch), nil
+}
+
+// GetEpochLastBlock - returns the number of the last block of a given epoch
+func GetEpochLastBlock(node string, epoch uint64) (uint64, error) {
+	return GetEpochLastBlockWithContext(context.Background(), rpc.NewClient(node), epoch)
+}
+
+// GetEpochLastBlockWithContext - returns the number of the last block of a given (also future) epoch using a given context and client
+func GetEpochLastBlockWithContext(ctx context.Context, client *rpc.Client, epoch uint64) (uint64, error) {
+	reply, err := client.Request(ctx, EpochLastBlockMethod, []interface{}{epoch})
+	if err != nil {
+		return 0, err
+	}
+
+	blockNumber, ok := reply["result"].(float64)
+	if !ok {
+		return 0, errors.New("block number missing from response")
+	}
+
+	return uint64(blockNumber), nil
 }
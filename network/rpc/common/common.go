@@ -10,23 +10,34 @@ import (
 
 // NewRPCClient - resolve the RPC/HTTP Messenger to use for remote commands using a node and a shardID
 func NewRPCClient(node string, shardID uint32, shardingStructure []goSDK_Sharding.RPCRoutes, retry *commonTypes.Retry) (*goSDK_RPC.HTTPMessenger, []goSDK_Sharding.RPCRoutes, error) {
+	shardNode, shardingStructure, err := ResolveShardNode(node, shardID, shardingStructure, retry)
+	if err != nil || shardNode == "" {
+		return nil, nil, err
+	}
+
+	return goSDK_RPC.NewHTTPHandler(shardNode), shardingStructure, nil
+}
+
+// ResolveShardNode - resolve the node to use for a given shardID using a node and the sharding structure
+// An empty node is returned if the shard doesn't exist in the sharding structure
+func ResolveShardNode(node string, shardID uint32, shardingStructure []goSDK_Sharding.RPCRoutes, retry *commonTypes.Retry) (string, []goSDK_Sharding.RPCRoutes, error) {
 	if utils.IsLocalNode(node) {
-		return goSDK_RPC.NewHTTPHandler(node), nil, nil
+		return node, nil, nil
 	}
 
 	if shardingStructure == nil || len(shardingStructure) == 0 {
 		structure, err := sharding.ShardingStructure(node, retry)
 		if err != nil {
-			return nil, nil, err
+			return "", nil, err
 		}
 		shardingStructure = structure
 	}
 
 	for _, shard := range shardingStructure {
 		if uint32(shard.ShardID) == shardID {
-			return goSDK_RPC.NewHTTPHandler(shard.HTTP), shardingStructure, nil
+			return shard.HTTP, shardingStructure, nil
 		}
 	}
 
-	return nil, nil, nil
+	return "", nil, nil
 }
@@ -0,0 +1,200 @@
+package nonces
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	ethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/harmony-one/go-lib/rpc"
+	"github.com/harmony-one/go-sdk/pkg/address"
+)
+
+// ClientResolver - resolves the client to use for a given shard
+type ClientResolver func(shardID uint32) (*rpc.Client, error)
+
+// NonceManager - hands out nonces locally for (address, shard) pairs so that many goroutines can send from the same account
+// The nonce is only fetched from the chain the first time an (address, shard) pair is used or when it has to be resynced
+type NonceManager struct {
+	resolver ClientResolver
+	mutex    sync.Mutex
+	accounts map[nonceKey]*accountNonces
+}
+
+type nonceKey struct {
+	address string // address - bech32 address, see accountAddress
+	shardID uint32
+}
+
+type accountNonces struct {
+	mutex       sync.Mutex
+	initialized bool
+	next        uint64
+	released    []uint64
+}
+
+// NewNonceManager - creates a new nonce manager using a given client resolver
+func NewNonceManager(resolver ClientResolver) *NonceManager {
+	return &NonceManager{
+		resolver: resolver,
+		accounts: make(map[nonceKey]*accountNonces),
+	}
+}
+
+// Reserve - reserves the next nonce for a given address and shard
+// Previously released nonces are handed out again (lowest first) before new nonces are allocated
+func (manager *NonceManager) Reserve(ctx context.Context, address string, shardID uint32) (uint64, error) {
+	account := manager.account(address, shardID)
+
+	account.mutex.Lock()
+	defer account.mutex.Unlock()
+
+	if !account.initialized {
+		if err := manager.sync(ctx, account, address, shardID); err != nil {
+			return 0, err
+		}
+	}
+
+	if len(account.released) > 0 {
+		nonce := account.released[0]
+		account.released = account.released[1:]
+		return nonce, nil
+	}
+
+	nonce := account.next
+	account.next++
+
+	return nonce, nil
+}
+
+// Release - returns a reserved nonce that wasn't used (e.g. because sending the tx failed) so that it can be handed out again
+func (manager *NonceManager) Release(address string, shardID uint32, nonce uint64) {
+	account := manager.account(address, shardID)
+
+	account.mutex.Lock()
+	defer account.mutex.Unlock()
+
+	if !account.initialized || nonce >= account.next {
+		return
+	}
+
+	for _, released := range account.released {
+		if released == nonce {
+			return
+		}
+	}
+
+	account.released = append(account.released, nonce)
+	sort.Slice(account.released, func(i, j int) bool { return account.released[i] < account.released[j] })
+
+	// Hand the tail back to the allocator so that released nonces at the end don't linger in the released list
+	for len(account.released) > 0 && account.released[len(account.released)-1] == account.next-1 {
+		account.released = account.released[:len(account.released)-1]
+		account.next--
+	}
+}
+
+// Resync - discards the local state and fetches the current (pending) nonce from the chain
+func (manager *NonceManager) Resync(ctx context.Context, address string, shardID uint32) error {
+	account := manager.account(address, shardID)
+
+	account.mutex.Lock()
+	defer account.mutex.Unlock()
+
+	return manager.sync(ctx, account, address, shardID)
+}
+
+// HandleError - resyncs the nonce for a given address and shard if err is a "nonce too low" error
+func (manager *NonceManager) HandleError(ctx context.Context, address string, shardID uint32, err error) (resynced bool, resyncErr error) {
+	if !IsNonceTooLow(err) {
+		return false, nil
+	}
+
+	if resyncErr = manager.Resync(ctx, address, shardID); resyncErr != nil {
+		return false, resyncErr
+	}
+
+	return true, nil
+}
+
+// HandleFailures - checks the transaction error sink for the given tx hashes and resyncs if any of them was rejected with "nonce too low"
+// Txs sent and waited for using a transactions.TxContext with the manager as its NonceSource are checked automatically, this is only required for txs that aren't waited for
+func (manager *NonceManager) HandleFailures(ctx context.Context, address string, shardID uint32, txHashes []string) (resynced bool, err error) {
+	client, err := manager.resolver(shardID)
+	if err != nil {
+		return false, err
+	}
+
+	failures, err := rpc.TransactionFailuresWithContext(ctx, client)
+	if err != nil {
+		return false, err
+	}
+
+	for _, txHash := range txHashes {
+		if failure, failed := rpc.FailureOccurredForTransaction(failures, txHash); failed && isNonceTooLowMessage(failure.ErrorMessage) {
+			if err := manager.Resync(ctx, address, shardID); err != nil {
+				return false, err
+			}
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// IsNonceTooLow - checks if a given error was caused by a nonce that has already been used
+func IsNonceTooLow(err error) bool {
+	return err != nil && isNonceTooLowMessage(err.Error())
+}
+
+func isNonceTooLowMessage(message string) bool {
+	return strings.Contains(strings.ToLower(message), "nonce too low")
+}
+
+func (manager *NonceManager) account(addr string, shardID uint32) *accountNonces {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	key := nonceKey{address: accountAddress(addr), shardID: shardID}
+	account, ok := manager.accounts[key]
+	if !ok {
+		account = &accountNonces{}
+		manager.accounts[key] = account
+	}
+
+	return account
+}
+
+// sync has to be called while holding the account mutex
+func (manager *NonceManager) sync(ctx context.Context, account *accountNonces, address string, shardID uint32) error {
+	client, err := manager.resolver(shardID)
+	if err != nil {
+		return err
+	}
+
+	nonce, err := CurrentNonceWithContext(ctx, client, address, "pending")
+	if err != nil {
+		return err
+	}
+
+	account.next = nonce
+	account.released = nil
+	account.initialized = true
+
+	return nil
+}
+
+// accountAddress - normalizes an address to its bech32 form so that the bech32 (one1...) and hex (0x...) forms of an account share their nonces
+// Strings that aren't valid addresses are kept as is instead of being mapped to the zero address
+func accountAddress(addr string) string {
+	if parsed, err := address.Bech32ToAddress(addr); err == nil {
+		return address.ToBech32(parsed)
+	}
+
+	if ethCommon.IsHexAddress(addr) {
+		return address.ToBech32(ethCommon.HexToAddress(addr))
+	}
+
+	return addr
+}
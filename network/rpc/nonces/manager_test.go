@@ -0,0 +1,48 @@
+package nonces_test
+
+import (
+	"context"
+	"testing"
+
+	ethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/harmony-one/go-lib/network/rpc/nonces"
+	"github.com/harmony-one/go-lib/rpc"
+	"github.com/harmony-one/go-lib/rpc/mock"
+	"github.com/harmony-one/go-sdk/pkg/address"
+)
+
+func TestNonceManagerNormalizesAddresses(t *testing.T) {
+	node := mock.NewNode()
+	defer node.Close()
+
+	hexAddress := "0x1111111111111111111111111111111111111111"
+	bech32Address := address.ToBech32(ethCommon.HexToAddress(hexAddress))
+	node.SetNonce(bech32Address, 5)
+
+	manager := nonces.NewNonceManager(func(shardID uint32) (*rpc.Client, error) {
+		return rpc.NewClient(node.URL), nil
+	})
+
+	reserve := func(addr string, expected uint64) {
+		t.Helper()
+
+		nonce, err := manager.Reserve(context.Background(), addr, 0)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if nonce != expected {
+			t.Errorf("expected nonce %d for %s, got %d", expected, addr, nonce)
+		}
+	}
+
+	reserve(bech32Address, 5)
+	reserve(hexAddress, 6)
+
+	manager.Release(hexAddress, 0, 6)
+	reserve(bech32Address, 6)
+
+	if calls := node.Calls("getTransactionCount"); calls != 1 {
+		t.Errorf("expected both address forms to share a single nonce sync, got %d syncs", calls)
+	}
+}
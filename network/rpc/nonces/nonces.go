@@ -1,11 +1,34 @@
 package nonces
 
 import (
-	"github.com/harmony-one/go-sdk/pkg/rpc"
+	"context"
+	"errors"
+
+	"github.com/harmony-one/go-lib/rpc"
+	"github.com/harmony-one/go-lib/utils"
+	"github.com/harmony-one/go-sdk/pkg/address"
+	goSdkRPC "github.com/harmony-one/go-sdk/pkg/rpc"
 	"github.com/harmony-one/go-sdk/pkg/transaction"
 )
 
 // CurrentNonce - get a specific nonce from input or from the network
-func CurrentNonce(rpcClient *rpc.HTTPMessenger, address string) uint64 {
+// Deprecated: CurrentNonce returns 0 if the nonce can't be fetched, use CurrentNonceWithContext instead
+func CurrentNonce(rpcClient goSdkRPC.T, address string) uint64 {
 	return transaction.GetNextNonce(address, rpcClient)
 }
+
+// CurrentNonceWithContext - get the current nonce for a given address using a given context and client
+// blockTag is either "latest" or "pending" (also includes txs currently in the tx pool)
+func CurrentNonceWithContext(ctx context.Context, client *rpc.Client, addr string, blockTag string) (uint64, error) {
+	reply, err := client.Request(ctx, goSdkRPC.Method.GetTransactionCount, []interface{}{address.Parse(addr), blockTag})
+	if err != nil {
+		return 0, err
+	}
+
+	transactionCount, ok := reply["result"].(string)
+	if !ok || transactionCount == "" {
+		return 0, errors.New("transaction count missing from response")
+	}
+
+	return utils.HexToDecimal(transactionCount)
+}
@@ -0,0 +1,202 @@
+package sharding
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	commonTypes "github.com/harmony-one/go-lib/network/types/common"
+	"github.com/harmony-one/go-lib/rpc"
+	"github.com/harmony-one/go-sdk/pkg/sharding"
+)
+
+// DefaultCacheTTL - time the sharding structure is cached for before it's refreshed
+var DefaultCacheTTL = 10 * time.Minute
+
+// Change - a change of the sharding structure detected when refreshing the cache
+type Change struct {
+	Previous []sharding.RPCRoutes
+	Current  []sharding.RPCRoutes
+}
+
+// ShardCountChanged - whether the number of shards changed, e.g. because of resharding
+func (change Change) ShardCountChanged() bool {
+	return len(change.Previous) != len(change.Current)
+}
+
+// Cache - caches the sharding structure of a network, refreshing it once the TTL expires
+// If a refresh fails the previously fetched structure keeps being served until the next refresh attempt
+type Cache struct {
+	Node     string              // Node - node used to fetch the sharding structure
+	Retry    *commonTypes.Retry  // Retry - retry settings used when fetching the sharding structure
+	TTL      time.Duration       // TTL - defaults to DefaultCacheTTL, a negative TTL disables expiry
+	OnChange func(change Change) // OnChange - called (outside of the cache lock) whenever a refresh detects a changed sharding structure
+
+	mutex     sync.RWMutex
+	fetching  sync.Mutex
+	routes    []sharding.RPCRoutes
+	fetchedAt time.Time
+}
+
+// NewCache - creates a new sharding structure cache for a given node
+func NewCache(node string, retry *commonTypes.Retry, ttl time.Duration) *Cache {
+	return &Cache{
+		Node:  node,
+		Retry: retry,
+		TTL:   ttl,
+	}
+}
+
+// Get - returns the cached sharding structure, fetching it if it hasn't been fetched yet or if the TTL has expired
+func (cache *Cache) Get(ctx context.Context) ([]sharding.RPCRoutes, error) {
+	if routes, ok := cache.fresh(); ok {
+		return routes, nil
+	}
+
+	cache.fetching.Lock()
+	defer cache.fetching.Unlock()
+
+	if routes, ok := cache.fresh(); ok {
+		return routes, nil
+	}
+
+	routes, _, err := cache.refresh(ctx)
+	if err != nil {
+		if cached := cache.Cached(); len(cached) > 0 {
+			return cached, nil
+		}
+		return nil, err
+	}
+
+	return routes, nil
+}
+
+// Refresh - fetches the sharding structure regardless of the TTL, returns the detected change or nil if the structure didn't change
+func (cache *Cache) Refresh(ctx context.Context) (*Change, error) {
+	cache.fetching.Lock()
+	defer cache.fetching.Unlock()
+
+	_, change, err := cache.refresh(ctx)
+	return change, err
+}
+
+// Set - seeds the cache with a sharding structure, e.g. one that was fetched elsewhere
+func (cache *Cache) Set(routes []sharding.RPCRoutes) {
+	if len(routes) == 0 {
+		return
+	}
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	cache.routes = routes
+	cache.fetchedAt = time.Now()
+}
+
+// Cached - returns the cached sharding structure without fetching it, regardless of whether it has expired
+func (cache *Cache) Cached() []sharding.RPCRoutes {
+	cache.mutex.RLock()
+	defer cache.mutex.RUnlock()
+
+	return cache.routes
+}
+
+// Expired - whether the sharding structure has to be (re)fetched
+func (cache *Cache) Expired() bool {
+	_, ok := cache.fresh()
+	return !ok
+}
+
+// Route - returns the routes for a given shard
+func (cache *Cache) Route(ctx context.Context, shardID uint32) (sharding.RPCRoutes, error) {
+	routes, err := cache.Get(ctx)
+	if err != nil {
+		return sharding.RPCRoutes{}, err
+	}
+
+	for _, route := range routes {
+		if uint32(route.ShardID) == shardID {
+			return route, nil
+		}
+	}
+
+	return sharding.RPCRoutes{}, fmt.Errorf("can't find shard %d in the sharding structure (%d shards)", shardID, len(routes))
+}
+
+// ShardCount - returns the number of shards
+func (cache *Cache) ShardCount(ctx context.Context) (int, error) {
+	routes, err := cache.Get(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(routes), nil
+}
+
+func (cache *Cache) fresh() ([]sharding.RPCRoutes, bool) {
+	cache.mutex.RLock()
+	defer cache.mutex.RUnlock()
+
+	if len(cache.routes) == 0 {
+		return nil, false
+	}
+
+	ttl := cache.TTL
+	if ttl == 0 {
+		ttl = DefaultCacheTTL
+	}
+
+	if ttl > 0 && time.Since(cache.fetchedAt) > ttl {
+		return cache.routes, false
+	}
+
+	return cache.routes, true
+}
+
+// refresh has to be called while holding the fetching lock
+func (cache *Cache) refresh(ctx context.Context) ([]sharding.RPCRoutes, *Change, error) {
+	if cache.Node == "" {
+		return nil, nil, fmt.Errorf("can't fetch the sharding structure without a node")
+	}
+
+	routes, err := ShardingStructureWithContext(ctx, rpc.NewClientWithRetry(cache.Node, cache.Retry))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(routes) == 0 {
+		return nil, nil, fmt.Errorf("node %s returned an empty sharding structure", cache.Node)
+	}
+
+	cache.mutex.Lock()
+	previous := cache.routes
+	cache.routes = routes
+	cache.fetchedAt = time.Now()
+	cache.mutex.Unlock()
+
+	if len(previous) == 0 || sameRoutes(previous, routes) {
+		return routes, nil, nil
+	}
+
+	change := &Change{Previous: previous, Current: routes}
+	if cache.OnChange != nil {
+		cache.OnChange(*change)
+	}
+
+	return routes, change, nil
+}
+
+func sameRoutes(a []sharding.RPCRoutes, b []sharding.RPCRoutes) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
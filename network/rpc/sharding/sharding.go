@@ -1,42 +1,41 @@
 package sharding
 
 import (
-	"time"
+	"context"
+	"encoding/json"
 
 	commonTypes "github.com/harmony-one/go-lib/network/types/common"
+	"github.com/harmony-one/go-lib/rpc"
+	goSdkRPC "github.com/harmony-one/go-sdk/pkg/rpc"
 	"github.com/harmony-one/go-sdk/pkg/sharding"
 	"github.com/pkg/errors"
 )
 
 // ShardingStructure - retrieve the sharding structure based on a given node
 func ShardingStructure(node string, retry *commonTypes.Retry) (routes []sharding.RPCRoutes, err error) {
-	if retry != nil && retry.Attempts > 0 {
-		ret := *retry
-		for {
-			ret.Attempts--
-
-			routes, err = shardingStructure(node)
-			if err == nil {
-				return routes, nil
-			}
-
-			if ret.Attempts > 0 {
-				time.Sleep(time.Second * time.Duration(ret.Wait))
-			} else {
-				break
-			}
-		}
-	} else {
-		routes, err = shardingStructure(node)
-	}
-
-	return routes, err
+	return ShardingStructureWithContext(context.Background(), rpc.NewClientWithRetry(node, retry))
 }
 
-func shardingStructure(node string) (routes []sharding.RPCRoutes, err error) {
-	routes, err = sharding.Structure(node)
+// ShardingStructureWithContext - retrieve the sharding structure using a given context and client
+func ShardingStructureWithContext(ctx context.Context, client *rpc.Client) (routes []sharding.RPCRoutes, err error) {
+	type structureResponse struct {
+		Result []sharding.RPCRoutes `json:"result"`
+		Error  *rpc.RPCError        `json:"error,omitempty"`
+	}
+
+	bytes, err := client.RawRequest(ctx, goSdkRPC.Method.GetShardingStructure, []interface{}{})
 	if err != nil {
 		return nil, errors.Wrapf(err, "network.ShardingStructure")
 	}
-	return routes, err
+
+	response := structureResponse{}
+	if err := json.Unmarshal(bytes, &response); err != nil {
+		return nil, errors.Wrapf(err, "network.ShardingStructure")
+	}
+
+	if response.Error != nil {
+		return nil, errors.Wrapf(*response.Error, "network.ShardingStructure")
+	}
+
+	return response.Result, nil
 }
@@ -0,0 +1,34 @@
+package sharding_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/harmony-one/go-lib/network/rpc/sharding"
+	"github.com/harmony-one/go-lib/rpc"
+	"github.com/harmony-one/go-lib/rpc/mock"
+)
+
+func TestShardingStructureReturnsRPCErrors(t *testing.T) {
+	node := mock.NewNode()
+	defer node.Close()
+
+	node.FailNext("getShardingStructure", 1, mock.ErrorCodeServer, "boom")
+
+	routes, err := sharding.ShardingStructureWithContext(context.Background(), rpc.NewClientWithRetry(node.URL, nil))
+
+	var rpcError rpc.RPCError
+	if !errors.As(err, &rpcError) || rpcError.Message != "boom" {
+		t.Fatalf("expected the rpc error, got %v", err)
+	}
+
+	if routes != nil {
+		t.Errorf("expected no routes, got %+v", routes)
+	}
+
+	routes, err = sharding.ShardingStructureWithContext(context.Background(), rpc.NewClientWithRetry(node.URL, nil))
+	if err != nil || len(routes) != 1 || routes[0].HTTP != node.URL {
+		t.Errorf("expected the default single shard structure, got %+v (error: %v)", routes, err)
+	}
+}
@@ -1,7 +1,17 @@
 package common
 
+const (
+	// BackoffFixed - wait the same amount of time between every retry
+	BackoffFixed = "fixed"
+	// BackoffExponential - double the wait time after every retry
+	BackoffExponential = "exponential"
+)
+
 // Retry - settings for RPC retries
 type Retry struct {
-	Attempts int
-	Wait     int
+	Attempts int     `json:"attempts" yaml:"attempts"`
+	Wait     int     `json:"wait" yaml:"wait"`
+	Backoff  string  `json:"backoff" yaml:"backoff"` // Backoff - BackoffFixed (default) or BackoffExponential
+	MaxWait  int     `json:"maxWait" yaml:"maxWait"` // MaxWait - upper bound (in seconds) for the wait time between retries, 0 means no upper bound
+	Jitter   float64 `json:"jitter" yaml:"jitter"`   // Jitter - fraction (0-1) of the wait time to randomly add to every wait
 }
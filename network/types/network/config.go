@@ -0,0 +1,387 @@
+package network
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	commonTypes "github.com/harmony-one/go-lib/network/types/common"
+	"github.com/harmony-one/go-lib/network/utils"
+	"github.com/harmony-one/go-lib/rpc"
+	goSDK_common "github.com/harmony-one/go-sdk/pkg/common"
+	"gopkg.in/yaml.v2"
+)
+
+// Supported network modes
+const (
+	ModeAPI    = "api"
+	ModeLocal  = "local"
+	ModeCustom = "custom"
+)
+
+// DefaultEnvPrefix - prefix of the environment variables used to override config values, e.g. HARMONY_NETWORK_NAME
+var DefaultEnvPrefix = "HARMONY_NETWORK_"
+
+// Config - declarative network configuration, loaded from a YAML or JSON file
+type Config struct {
+	Name          string            `json:"name" yaml:"name"`
+	Mode          string            `json:"mode" yaml:"mode"` // Mode - ModeAPI (default), ModeLocal or ModeCustom
+	Node          string            `json:"node" yaml:"node"` // Node - if set it will be used as the node address everywhere
+	ChainID       string            `json:"chainId" yaml:"chainId"`
+	ShardCount    int               `json:"shardCount" yaml:"shardCount"` // ShardCount - number of shards to generate node addresses for when no shards are specified
+	Shards        []ShardConfig     `json:"shards" yaml:"shards"`
+	NodeSelection string            `json:"nodeSelection" yaml:"nodeSelection"`
+	Retry         commonTypes.Retry `json:"retry" yaml:"retry"`
+	Gas           Gas               `json:"gas" yaml:"gas"`
+}
+
+// ShardConfig - the nodes serving a given shard
+type ShardConfig struct {
+	ShardID uint32   `json:"shard" yaml:"shard"`
+	Nodes   []string `json:"nodes" yaml:"nodes"`
+}
+
+// ConfigError - a config validation error, Key points to the offending key (or environment variable)
+type ConfigError struct {
+	Key string
+	Err error
+}
+
+// Error - returns the error message, prefixed with the offending key
+func (e *ConfigError) Error() string {
+	return fmt.Sprintf("network config: %s: %v", e.Key, e.Err)
+}
+
+// Unwrap - returns the underlying error
+func (e *ConfigError) Unwrap() error {
+	return e.Err
+}
+
+// LoadConfig - loads a network from a YAML/JSON config file (determined by the file extension), applying environment overrides using DefaultEnvPrefix
+func LoadConfig(path string) (*Network, error) {
+	config, err := ReadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := config.ApplyEnv(DefaultEnvPrefix); err != nil {
+		return nil, err
+	}
+
+	return config.Network()
+}
+
+// ReadConfig - reads a YAML/JSON config file without applying any environment overrides
+func ReadConfig(path string) (*Config, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	format := "yaml"
+	if strings.ToLower(filepath.Ext(path)) == ".json" {
+		format = "json"
+	}
+
+	return ParseConfig(data, format)
+}
+
+// ParseConfig - parses a config in a given format ("yaml" or "json"), unknown keys are rejected
+func ParseConfig(data []byte, format string) (*Config, error) {
+	config := &Config{}
+
+	switch strings.ToLower(format) {
+	case "json":
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(config); err != nil {
+			return nil, fmt.Errorf("network config: %v", err)
+		}
+	case "yaml", "yml":
+		if err := yaml.UnmarshalStrict(data, config); err != nil {
+			return nil, fmt.Errorf("network config: %v", err)
+		}
+	default:
+		return nil, fmt.Errorf("network config: unsupported format %s", format)
+	}
+
+	return config, nil
+}
+
+// ApplyEnv - overrides config values using environment variables with a given prefix:
+// NAME, MODE, NODE, CHAIN_ID, SHARD_COUNT, NODE_SELECTION, SHARD_<ID>_NODES (comma separated),
+// RETRY_ATTEMPTS, RETRY_WAIT, RETRY_BACKOFF, RETRY_MAX_WAIT, RETRY_JITTER, GAS_COST, GAS_LIMIT and GAS_PRICE
+func (config *Config) ApplyEnv(prefix string) error {
+	stringFields := map[string]*string{
+		"NAME":           &config.Name,
+		"MODE":           &config.Mode,
+		"NODE":           &config.Node,
+		"CHAIN_ID":       &config.ChainID,
+		"NODE_SELECTION": &config.NodeSelection,
+		"RETRY_BACKOFF":  &config.Retry.Backoff,
+		"GAS_COST":       &config.Gas.RawCost,
+		"GAS_PRICE":      &config.Gas.RawPrice,
+	}
+	for key, field := range stringFields {
+		if value, ok := os.LookupEnv(prefix + key); ok {
+			*field = value
+		}
+	}
+
+	intFields := map[string]*int{
+		"SHARD_COUNT":    &config.ShardCount,
+		"RETRY_ATTEMPTS": &config.Retry.Attempts,
+		"RETRY_WAIT":     &config.Retry.Wait,
+		"RETRY_MAX_WAIT": &config.Retry.MaxWait,
+	}
+	for key, field := range intFields {
+		if value, ok := os.LookupEnv(prefix + key); ok {
+			parsed, err := strconv.Atoi(value)
+			if err != nil {
+				return &ConfigError{Key: prefix + key, Err: fmt.Errorf("%s isn't a valid integer", value)}
+			}
+			*field = parsed
+		}
+	}
+
+	if value, ok := os.LookupEnv(prefix + "GAS_LIMIT"); ok {
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return &ConfigError{Key: prefix + "GAS_LIMIT", Err: fmt.Errorf("%s isn't a valid integer", value)}
+		}
+		config.Gas.Limit = parsed
+	}
+
+	if value, ok := os.LookupEnv(prefix + "RETRY_JITTER"); ok {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return &ConfigError{Key: prefix + "RETRY_JITTER", Err: fmt.Errorf("%s isn't a valid number", value)}
+		}
+		config.Retry.Jitter = parsed
+	}
+
+	shardPrefix := prefix + "SHARD_"
+	for _, env := range os.Environ() {
+		parts := strings.SplitN(env, "=", 2)
+		if len(parts) != 2 || !strings.HasPrefix(parts[0], shardPrefix) || !strings.HasSuffix(parts[0], "_NODES") {
+			continue
+		}
+
+		rawShardID := strings.TrimSuffix(strings.TrimPrefix(parts[0], shardPrefix), "_NODES")
+		shardID, err := strconv.ParseUint(rawShardID, 10, 32)
+		if err != nil {
+			return &ConfigError{Key: parts[0], Err: fmt.Errorf("%s isn't a valid shard id", rawShardID)}
+		}
+
+		config.setShardNodes(uint32(shardID), splitNodes(parts[1]))
+	}
+
+	return nil
+}
+
+// Validate - validates the config, the returned *ConfigError points to the first offending key
+func (config *Config) Validate() error {
+	if config.Name == "" {
+		return &ConfigError{Key: "name", Err: fmt.Errorf("a network name is required")}
+	}
+
+	if config.ChainID != "" {
+		if _, err := goSDK_common.StringToChainID(config.ChainID); err != nil {
+			return &ConfigError{Key: "chainId", Err: err}
+		}
+	} else if utils.NormalizedNetworkName(config.Name) == "" {
+		return &ConfigError{Key: "name", Err: fmt.Errorf("unknown network %s - specify a chainId for custom networks", config.Name)}
+	}
+
+	switch strings.ToLower(config.Mode) {
+	case "", ModeAPI, ModeLocal:
+	case ModeCustom:
+		if len(config.Shards) == 0 && config.Node == "" {
+			return &ConfigError{Key: "shards", Err: fmt.Errorf("at least one shard or a node is required in %s mode", ModeCustom)}
+		}
+	default:
+		return &ConfigError{Key: "mode", Err: fmt.Errorf("unsupported mode %s - supported modes are %s, %s and %s", config.Mode, ModeAPI, ModeLocal, ModeCustom)}
+	}
+
+	if config.Node != "" {
+		if err := validateNodeURL(config.Node); err != nil {
+			return &ConfigError{Key: "node", Err: err}
+		}
+	}
+
+	if config.ShardCount < 0 {
+		return &ConfigError{Key: "shardCount", Err: fmt.Errorf("shard count can't be negative")}
+	}
+
+	seen := make(map[uint32]bool)
+	for i, shard := range config.Shards {
+		if seen[shard.ShardID] {
+			return &ConfigError{Key: fmt.Sprintf("shards[%d].shard", i), Err: fmt.Errorf("shard %d is specified more than once", shard.ShardID)}
+		}
+		seen[shard.ShardID] = true
+
+		if len(shard.Nodes) == 0 {
+			return &ConfigError{Key: fmt.Sprintf("shards[%d].nodes", i), Err: fmt.Errorf("at least one node is required for shard %d", shard.ShardID)}
+		}
+
+		for j, node := range shard.Nodes {
+			if err := validateNodeURL(node); err != nil {
+				return &ConfigError{Key: fmt.Sprintf("shards[%d].nodes[%d]", i, j), Err: err}
+			}
+		}
+	}
+
+	switch config.NodeSelection {
+	case "", rpc.SelectionRoundRobin, rpc.SelectionLatency:
+	default:
+		return &ConfigError{Key: "nodeSelection", Err: fmt.Errorf("unsupported node selection %s - supported values are %s and %s", config.NodeSelection, rpc.SelectionRoundRobin, rpc.SelectionLatency)}
+	}
+
+	if config.Retry.Attempts < 0 {
+		return &ConfigError{Key: "retry.attempts", Err: fmt.Errorf("attempts can't be negative")}
+	}
+
+	if config.Retry.Wait < 0 {
+		return &ConfigError{Key: "retry.wait", Err: fmt.Errorf("wait can't be negative")}
+	}
+
+	if config.Retry.MaxWait < 0 {
+		return &ConfigError{Key: "retry.maxWait", Err: fmt.Errorf("max wait can't be negative")}
+	}
+
+	switch config.Retry.Backoff {
+	case "", commonTypes.BackoffFixed, commonTypes.BackoffExponential:
+	default:
+		return &ConfigError{Key: "retry.backoff", Err: fmt.Errorf("unsupported backoff %s - supported values are %s and %s", config.Retry.Backoff, commonTypes.BackoffFixed, commonTypes.BackoffExponential)}
+	}
+
+	if config.Retry.Jitter < 0 || config.Retry.Jitter > 1 {
+		return &ConfigError{Key: "retry.jitter", Err: fmt.Errorf("jitter has to be between 0 and 1")}
+	}
+
+	if config.Gas.RawCost != "" {
+		if _, err := goSDK_common.NewDecFromString(config.Gas.RawCost); err != nil {
+			return &ConfigError{Key: "gas.cost", Err: err}
+		}
+	}
+
+	if config.Gas.RawPrice != "" {
+		if _, err := goSDK_common.NewDecFromString(config.Gas.RawPrice); err != nil {
+			return &ConfigError{Key: "gas.price", Err: err}
+		}
+	}
+
+	if config.Gas.Limit < -1 {
+		return &ConfigError{Key: "gas.limit", Err: fmt.Errorf("gas limit can't be negative")}
+	}
+
+	return nil
+}
+
+// Network - validates the config and builds a fully initialized network
+// No remote calls are made - the sharding structure and rpc clients are resolved lazily when first used
+func (config *Config) Network() (*Network, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	network := &Network{
+		Name:          config.Name,
+		Mode:          strings.ToLower(config.Mode),
+		Node:          config.Node,
+		Retry:         config.Retry,
+		Gas:           config.Gas,
+		Shards:        make(map[uint32]Shard),
+		NodeSelection: config.NodeSelection,
+	}
+
+	if network.Mode == "" {
+		network.Mode = ModeAPI
+	}
+
+	if err := network.Gas.Initialize(); err != nil {
+		return nil, &ConfigError{Key: "gas", Err: err}
+	}
+
+	if config.ChainID != "" {
+		chainID, err := goSDK_common.StringToChainID(config.ChainID)
+		if err != nil {
+			return nil, &ConfigError{Key: "chainId", Err: err}
+		}
+		network.ChainID = chainID
+	} else if err := network.SetChainID(); err != nil {
+		return nil, &ConfigError{Key: "name", Err: err}
+	}
+
+	if len(config.Shards) > 0 {
+		for _, shard := range config.Shards {
+			network.Shards[shard.ShardID] = newShard(shard.Nodes, network.NodeSelection)
+		}
+	} else if network.Mode != ModeCustom {
+		for shardID := uint32(0); shardID < uint32(config.ShardCount); shardID++ {
+			node := utils.GenerateNodeAddress(network.Name, network.Mode, shardID)
+			network.Shards[shardID] = Shard{Node: node, Nodes: []string{node}}
+		}
+	}
+
+	network.ShardCount = len(network.Shards)
+
+	return network, nil
+}
+
+func (config *Config) setShardNodes(shardID uint32, nodes []string) {
+	for i, shard := range config.Shards {
+		if shard.ShardID == shardID {
+			config.Shards[i].Nodes = nodes
+			return
+		}
+	}
+
+	config.Shards = append(config.Shards, ShardConfig{ShardID: shardID, Nodes: nodes})
+	sort.SliceStable(config.Shards, func(i, j int) bool { return config.Shards[i].ShardID < config.Shards[j].ShardID })
+}
+
+// newShard - creates a shard without resolving its rpc client, a pool is only created for shards with multiple nodes
+func newShard(nodes []string, selection string) Shard {
+	shard := Shard{Node: nodes[0], Nodes: nodes}
+	if len(nodes) > 1 {
+		shard.Pool = rpc.NewNodePool(nodes, selection)
+	}
+
+	return shard
+}
+
+func splitNodes(value string) []string {
+	nodes := []string{}
+	for _, node := range strings.Split(value, ",") {
+		if node = strings.TrimSpace(node); node != "" {
+			nodes = append(nodes, node)
+		}
+	}
+
+	return nodes
+}
+
+func validateNodeURL(node string) error {
+	parsed, err := url.Parse(node)
+	if err != nil {
+		return err
+	}
+
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return fmt.Errorf("node %s has to be a http or https url", node)
+	}
+
+	if parsed.Host == "" {
+		return fmt.Errorf("node %s is missing a host", node)
+	}
+
+	return nil
+}
@@ -1,83 +1,146 @@
 package network
 
 import (
+	"context"
 	"fmt"
 	"sync"
+	"time"
 
+	"github.com/harmony-one/go-lib/logging"
 	"github.com/harmony-one/go-lib/network/rpc/balances"
 	"github.com/harmony-one/go-lib/network/rpc/block"
 	commonRPC "github.com/harmony-one/go-lib/network/rpc/common"
 	"github.com/harmony-one/go-lib/network/rpc/nonces"
+	"github.com/harmony-one/go-lib/network/rpc/sharding"
 	commonTypes "github.com/harmony-one/go-lib/network/types/common"
 	"github.com/harmony-one/go-lib/network/utils"
+	"github.com/harmony-one/go-lib/rpc"
 	goSDK_common "github.com/harmony-one/go-sdk/pkg/common"
 	goSDK_RPC "github.com/harmony-one/go-sdk/pkg/rpc"
-	"github.com/harmony-one/go-sdk/pkg/sharding"
 	goSDK_sharding "github.com/harmony-one/go-sdk/pkg/sharding"
 	"github.com/harmony-one/harmony/numeric"
 )
 
 // Network - represents a network configuration
+// A network is safe for concurrent use, shard resolution is guarded by an internal lock and rpc clients are created once per shard
+// Configure the exported fields before sharing the network between goroutines and don't copy a network after it's been used
 type Network struct {
 	Name              string
 	Mode              string
 	Node              string // Node - override any other node settings, if Node is set it will be used as the node address everywhere
 	ChainID           *goSDK_common.ChainID
 	Retry             commonTypes.Retry
+	Gas               Gas // Gas - default gas settings for the network, populated when loading the network from a config file
 	Shards            map[uint32]Shard
 	ShardCount        int
 	ShardingStructure []goSDK_sharding.RPCRoutes
-	Mutex             sync.Mutex
+	Logger            logging.Logger // Logger - logger used for this network, falls back to the global logger if nil
+	NodeSelection     string         // NodeSelection - rpc.SelectionRoundRobin (default) or rpc.SelectionLatency, used for shards with multiple nodes
+	ShardingTTL       time.Duration  // ShardingTTL - time the sharding structure is cached for, defaults to sharding.DefaultCacheTTL
+
+	mutex        sync.RWMutex
+	shardLocks   map[uint32]*sync.Mutex
+	topology     *sharding.Cache
+	nonceManager *nonces.NonceManager
 }
 
 // Shard - represents a shard configuration
 type Shard struct {
 	Node      string
+	Nodes     []string      // Nodes - all endpoints serving the shard, Node is the first one
+	Pool      *rpc.NodePool // Pool - only set if the shard has more than one node, used for load balancing and failover
 	RPCClient *goSDK_RPC.HTTPMessenger
+	Client    *rpc.Client
 }
 
 // Initialize - initializes a given network
-func (network *Network) Initialize() {
+func (network *Network) Initialize() error {
+	network.mutex.Lock()
 	network.ShardCount = len(network.ShardingStructure)
-	network.SetChainID()
+	network.mutex.Unlock()
+
+	return network.SetChainID()
 }
 
-// SetChainID - sets the chain id for a given network
-func (network *Network) SetChainID() {
+// SetChainID - sets the chain id for a given network, returns utils.ErrUnknownNetwork if the network hasn't been registered (see utils.RegisterNetwork)
+func (network *Network) SetChainID() error {
 	chainID, err := utils.IdentifyNetworkChainID(network.Name)
-	if chainID == nil || err != nil {
-		network.ChainID = &goSDK_common.Chain.TestNet
-	} else {
-		network.ChainID = chainID
+	if err != nil {
+		return err
 	}
+
+	network.mutex.Lock()
+	network.ChainID = chainID
+	network.mutex.Unlock()
+
+	return nil
+}
+
+// LockPeriodInEpochs - returns the number of epochs undelegated tokens stay locked on the network, returns utils.ErrUnknownNetwork if the network hasn't been registered
+func (network *Network) LockPeriodInEpochs() (int, error) {
+	return utils.LookupLockPeriod(network.Name)
+}
+
+// GetShard - returns the configuration for a given shard
+func (network *Network) GetShard(shardID uint32) (Shard, bool) {
+	network.mutex.RLock()
+	defer network.mutex.RUnlock()
+
+	shard, ok := network.Shards[shardID]
+	return shard, ok
 }
 
 // ShardsToMap - convert the shards to a map[uint32]string
 func (network *Network) ShardsToMap() (shards map[uint32]string) {
 	shards = make(map[uint32]string)
 
+	network.mutex.RLock()
+	defer network.mutex.RUnlock()
+
 	for shardID, shard := range network.Shards {
-		shards[shardID] = shard.Node
+		shards[shardID] = shard.selectNode()
 	}
 
 	return shards
 }
 
-// NodeAddress - generates a node address given the network's name and mode + the supplied shardID
+// NodeAddress - generates a node address given the network's name and mode + the supplied shardID, an empty string is returned for unregistered networks
 func (network *Network) NodeAddress(shardID uint32) string {
 	if network.Node != "" {
 		return network.Node
 	}
 
-	shard, ok := network.Shards[shardID]
+	shard, ok := network.GetShard(shardID)
 	if ok && shard.Node != "" {
-		return shard.Node
+		return shard.selectNode()
 	}
 
 	generated := utils.GenerateNodeAddress(network.Name, network.Mode, shardID)
-	network.Shards[shardID] = Shard{Node: generated}
+	if generated == "" {
+		return generated
+	}
+
+	network.mutex.Lock()
+	defer network.mutex.Unlock()
+
+	shard = network.Shards[shardID]
+	if shard.Node == "" {
+		shard.Node = generated
+		shard.Nodes = []string{generated}
+		network.storeShard(shardID, shard)
+	}
+
+	return shard.selectNode()
+}
+
+// Log - returns the logger for the network, entries are tagged with the network name
+func (network *Network) Log() logging.Logger {
+	return logging.Resolve(network.Logger).With(logging.F("network", network.Name))
+}
 
-	return network.Shards[shardID].Node
+// WithLogger - returns a context carrying the network's logger, used by context aware operations such as confirmation watchers
+func (network *Network) WithLogger(ctx context.Context) context.Context {
+	return logging.WithLogger(ctx, network.Log())
 }
 
 // IdentifyChainID - identifies a chain id given a network name
@@ -90,6 +153,26 @@ func (network *Network) GetAllShardBalances(address string) (map[uint32]numeric.
 	return balances.GetAllShardBalances(address, network.ShardsToMap(), &network.Retry)
 }
 
+// GetShardBalances - concurrently gets the balance in every shard for a given address, reporting errors per shard
+func (network *Network) GetShardBalances(address string) []balances.ShardBalance {
+	return network.GetShardBalancesWithContext(context.Background(), address, 0)
+}
+
+// GetShardBalancesWithContext - concurrently gets the balance in every shard using a given context and maximum concurrency
+func (network *Network) GetShardBalancesWithContext(ctx context.Context, address string, concurrency int) []balances.ShardBalance {
+	return balances.GetShardBalances(ctx, address, network.ShardsToMap(), &network.Retry, concurrency)
+}
+
+// GetBalances - gets the balances for multiple addresses on a given shard using batched requests
+func (network *Network) GetBalances(ctx context.Context, shardID uint32, addresses []string) ([]balances.AddressBalance, error) {
+	client, err := network.Client(shardID)
+	if err != nil {
+		return nil, err
+	}
+
+	return balances.GetBalancesWithContext(ctx, client, addresses)
+}
+
 // GetShardBalance - gets the balance for a given network, mode, address and shard
 func (network *Network) GetShardBalance(address string, shardID uint32) (numeric.Dec, error) {
 	return balances.GetShardBalance(address, shardID, network.ShardsToMap(), &network.Retry)
@@ -101,55 +184,299 @@ func (network *Network) GetTotalBalance(address string) (numeric.Dec, error) {
 }
 
 // CurrentNonce - gets the current nonce for a given network, mode and address
+// Deprecated: CurrentNonce logs the error and returns 0 if the nonce can't be fetched, use CurrentNonceWithContext instead
 func (network *Network) CurrentNonce(address string, shardID uint32) uint64 {
-	rpcClient, err := network.RPCClient(shardID)
+	nonce, err := network.CurrentNonceWithContext(context.Background(), address, shardID)
 	if err != nil {
+		network.Log().Log(logging.WarnLevel, "failed to fetch the current nonce", logging.F("address", address), logging.ShardID(shardID), logging.Err(err))
 		return 0
 	}
-	return nonces.CurrentNonce(rpcClient, address)
+	return nonce
+}
+
+// CurrentNonceWithContext - gets the current nonce for a given address and shard using a given context
+func (network *Network) CurrentNonceWithContext(ctx context.Context, address string, shardID uint32) (uint64, error) {
+	client, err := network.Client(shardID)
+	if err != nil {
+		return 0, err
+	}
+	return nonces.CurrentNonceWithContext(ctx, client, address, "latest")
+}
+
+// NonceManager - returns the network's nonce manager, used to hand out nonces locally when sending lots of txs from the same account
+func (network *Network) NonceManager() *nonces.NonceManager {
+	network.mutex.Lock()
+	defer network.mutex.Unlock()
+
+	if network.nonceManager == nil {
+		network.nonceManager = nonces.NewNonceManager(network.Client)
+	}
+
+	return network.nonceManager
 }
 
-// SetShardingStructure - sets the sharding structure for the network
+// SetShardingStructure - sets the sharding structure for the network, an already set sharding structure is kept
 func (network *Network) SetShardingStructure(shardingStructure []goSDK_sharding.RPCRoutes) {
-	if network.ShardingStructure == nil && len(network.ShardingStructure) == 0 && len(shardingStructure) > 0 {
+	network.mutex.Lock()
+	defer network.mutex.Unlock()
+
+	if len(network.ShardingStructure) == 0 && len(shardingStructure) > 0 {
 		network.ShardingStructure = shardingStructure
+		if network.topology != nil && len(network.topology.Cached()) == 0 {
+			network.topology.Set(shardingStructure)
+		}
+	}
+}
+
+// GetShardingStructure - returns the currently known sharding structure for the network without fetching it
+func (network *Network) GetShardingStructure() []goSDK_sharding.RPCRoutes {
+	network.mutex.RLock()
+	defer network.mutex.RUnlock()
+
+	return network.ShardingStructure
+}
+
+// Topology - returns the sharding structure cache of the network, the structure is fetched using Node or the node of shard 0
+func (network *Network) Topology() (*sharding.Cache, error) {
+	network.mutex.RLock()
+	topology := network.topology
+	network.mutex.RUnlock()
+
+	if topology != nil {
+		return topology, nil
 	}
+
+	node := network.Node
+	if node == "" {
+		node = network.NodeAddress(0)
+	}
+
+	if node == "" {
+		return nil, network.unknownNodeError(0)
+	}
+
+	network.mutex.Lock()
+	defer network.mutex.Unlock()
+
+	if network.topology == nil {
+		network.topology = sharding.NewCache(node, &network.Retry, network.ShardingTTL)
+		network.topology.Set(network.ShardingStructure)
+		network.topology.OnChange = network.applyShardingChange
+	}
+
+	return network.topology, nil
+}
+
+// ShardingStructureWithContext - returns the sharding structure, fetching it if it hasn't been fetched yet or if the cached structure has expired
+func (network *Network) ShardingStructureWithContext(ctx context.Context) ([]goSDK_sharding.RPCRoutes, error) {
+	topology, err := network.Topology()
+	if err != nil {
+		return nil, err
+	}
+
+	shardingStructure, err := topology.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	network.SetShardingStructure(shardingStructure)
+
+	return shardingStructure, nil
+}
+
+// RefreshShardingStructure - fetches the sharding structure regardless of the cache TTL, returns the detected change or nil if it didn't change
+// Cached shard clients are reset whenever the structure changes so that they're resolved using the new structure
+func (network *Network) RefreshShardingStructure(ctx context.Context) (*sharding.Change, error) {
+	topology, err := network.Topology()
+	if err != nil {
+		return nil, err
+	}
+
+	change, err := topology.Refresh(ctx)
+	if err != nil {
+		return nil, err
+	}
+	network.SetShardingStructure(topology.Cached())
+
+	return change, nil
 }
 
-// GetCurrentEpoch - returns current epoch
+// ShardRoute - returns the routes of a given shard, an error is returned if the shard doesn't exist
+func (network *Network) ShardRoute(ctx context.Context, shardID uint32) (goSDK_sharding.RPCRoutes, error) {
+	topology, err := network.Topology()
+	if err != nil {
+		return goSDK_sharding.RPCRoutes{}, err
+	}
+
+	route, err := topology.Route(ctx, shardID)
+	if err != nil {
+		return route, fmt.Errorf("%s network: %w", network.Name, err)
+	}
+	network.SetShardingStructure(topology.Cached())
+
+	return route, nil
+}
+
+// CurrentShardCount - returns the number of shards according to the (cached) sharding structure
+func (network *Network) CurrentShardCount(ctx context.Context) (int, error) {
+	shardingStructure, err := network.ShardingStructureWithContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(shardingStructure), nil
+}
+
+// CurrentEpoch - returns current epoch
 func (network *Network) CurrentEpoch(shardID uint32) (uint32, error) {
-	node := network.ShardingStructure[shardID].HTTP
-	return block.GetCurrentEpoch(node)
+	route, err := network.ShardRoute(context.Background(), shardID)
+	if err != nil {
+		return 0, err
+	}
+
+	return block.GetCurrentEpochWithContext(context.Background(), rpc.NewClientWithRetry(route.HTTP, &network.Retry))
 }
 
 // RPCClient - resolve the RPC/HTTP Messenger to use for remote commands
+// A messenger is bound to a single node, for shards with multiple nodes use Messenger (or Client) to get load balancing and failover
 func (network *Network) RPCClient(shardID uint32) (*goSDK_RPC.HTTPMessenger, error) {
 	if len(network.Node) > 0 {
-		client, shardingStructure, err := commonRPC.NewRPCClient(network.Node, shardID, network.ShardingStructure, &network.Retry)
+		client, shardingStructure, err := commonRPC.NewRPCClient(network.Node, shardID, network.resolveShardingStructure(network.Node), &network.Retry)
 		network.SetShardingStructure(shardingStructure)
 		return client, err
 	}
 
-	shard, ok := network.Shards[shardID]
-	if ok && shard.RPCClient != nil {
-		return shard.RPCClient, nil
+	if client, ok, err := network.cachedRPCClient(shardID); ok {
+		return client, err
+	}
+
+	lock := network.shardLock(shardID)
+	lock.Lock()
+	defer lock.Unlock()
+
+	if client, ok, err := network.cachedRPCClient(shardID); ok {
+		return client, err
 	}
 
 	node := network.NodeAddress(shardID)
-	client, shardingStructure, err := commonRPC.NewRPCClient(node, shardID, network.ShardingStructure, &network.Retry)
+	if node == "" {
+		return nil, network.unknownNodeError(shardID)
+	}
+
+	client, shardingStructure, err := commonRPC.NewRPCClient(node, shardID, network.resolveShardingStructure(node), &network.Retry)
 	network.SetShardingStructure(shardingStructure)
-	network.Mutex.Lock()
-	network.Shards[shardID] = generateShardType(node, shardID, shardingStructure)
-	network.Mutex.Unlock()
+	if err != nil || client == nil {
+		return client, err
+	}
 
-	return client, err
+	network.updateShard(shardID, func(shard *Shard) {
+		shard.RPCClient = client
+	})
+
+	return client, nil
+}
+
+// Messenger - resolve the messenger to use with the legacy functions for a given shard
+// Shards with multiple nodes are served by the shard's pooled client (see Client), so legacy calls get the pool's failover, ejection and latency sampling
+func (network *Network) Messenger(shardID uint32) (goSDK_RPC.T, error) {
+	if shard, ok := network.GetShard(shardID); ok && shard.Pool != nil && len(network.Node) == 0 {
+		client, err := network.Client(shardID)
+		if err != nil {
+			return nil, err
+		}
+		return client, nil
+	}
+
+	messenger, err := network.RPCClient(shardID)
+	if err != nil || messenger == nil {
+		return nil, err
+	}
+
+	return messenger, nil
+}
+
+// Client - resolve the context aware RPC client to use for remote commands for a given shard
+func (network *Network) Client(shardID uint32) (*rpc.Client, error) {
+	if len(network.Node) > 0 {
+		return network.resolveClient(network.Node, shardID)
+	}
+
+	if shard, ok := network.GetShard(shardID); ok && shard.Client != nil {
+		return shard.Client, nil
+	}
+
+	lock := network.shardLock(shardID)
+	lock.Lock()
+	defer lock.Unlock()
+
+	shard, ok := network.GetShard(shardID)
+	if ok && shard.Client != nil {
+		return shard.Client, nil
+	}
+
+	var client *rpc.Client
+	if ok && shard.Pool != nil {
+		client = rpc.NewPoolClient(shard.Pool, &network.Retry)
+	} else {
+		node := network.NodeAddress(shardID)
+		if node == "" {
+			return nil, network.unknownNodeError(shardID)
+		}
+
+		resolved, err := network.resolveClient(node, shardID)
+		if err != nil {
+			return nil, err
+		}
+		client = resolved
+	}
+
+	network.updateShard(shardID, func(shard *Shard) {
+		shard.Client = client
+	})
+
+	return client, nil
+}
+
+// SetShardNodes - configures the pool of nodes to use for a given shard, replacing any previously created clients for the shard
+func (network *Network) SetShardNodes(shardID uint32, nodes []string) {
+	shard := generateShardType(nodes, shardID, network.GetShardingStructure(), network.NodeSelection)
+
+	lock := network.shardLock(shardID)
+	lock.Lock()
+	defer lock.Unlock()
+
+	network.mutex.Lock()
+	defer network.mutex.Unlock()
+
+	network.storeShard(shardID, shard)
+}
+
+// StartHealthChecks - periodically health checks the nodes of every shard that has more than one node until the context is done
+func (network *Network) StartHealthChecks(ctx context.Context, interval time.Duration) {
+	network.mutex.RLock()
+	defer network.mutex.RUnlock()
+
+	for _, shard := range network.Shards {
+		if shard.Pool != nil {
+			shard.Pool.StartHealthChecks(ctx, interval)
+		}
+	}
 }
 
 // GenerateShardSetup - generate the shard setup based on a given node
 func GenerateShardSetup(node string, network string, mode string, nodes []string) (shards map[uint32]Shard, shardingStructure []goSDK_sharding.RPCRoutes, err error) {
+	shardNodes := [][]string{}
+	for _, customNode := range nodes {
+		shardNodes = append(shardNodes, []string{customNode})
+	}
+
+	return GenerateShardPoolSetup(node, network, mode, shardNodes, rpc.SelectionRoundRobin)
+}
+
+// GenerateShardPoolSetup - generate the shard setup based on a given node, in custom mode every shard can be served by a pool of nodes
+func GenerateShardPoolSetup(node string, network string, mode string, nodes [][]string, selection string) (shards map[uint32]Shard, shardingStructure []goSDK_sharding.RPCRoutes, err error) {
 	shards = make(map[uint32]Shard)
 
-	shardingStructure, err = sharding.Structure(node)
+	shardingStructure, err = sharding.ShardingStructure(node, nil)
 	if err != nil {
 		return shards, shardingStructure, fmt.Errorf("can't connect to the %s network using node %s - make sure the network is online and that you haven't gotten rate-limited", network, node)
 	}
@@ -157,27 +484,162 @@ func GenerateShardSetup(node string, network string, mode string, nodes []string
 	if mode == "api" {
 		for i := 0; i < len(shardingStructure); i++ {
 			shardNode := utils.GenerateNodeAddress(network, mode, uint32(i))
-			shards[uint32(i)] = generateShardType(shardNode, uint32(i), shardingStructure)
+			shards[uint32(i)] = generateShardType([]string{shardNode}, uint32(i), shardingStructure, selection)
 		}
 	} else {
 		if len(nodes) == len(shardingStructure) {
-			for i, customNode := range nodes {
-				shards[uint32(i)] = generateShardType(customNode, uint32(i), shardingStructure)
+			for i, customNodes := range nodes {
+				if len(customNodes) == 0 {
+					return shards, shardingStructure, fmt.Errorf("no nodes specified for shard %d of the network %s", i, network)
+				}
+				shards[uint32(i)] = generateShardType(customNodes, uint32(i), shardingStructure, selection)
 			}
 		} else {
-			return shards, shardingStructure, fmt.Errorf("the node count for the nodes you've specified (%d) doens't match the expected node count (%d) for the network %s", len(nodes), len(shardingStructure), network, node)
+			return shards, shardingStructure, fmt.Errorf("the node count for the nodes you've specified (%d) doens't match the expected node count (%d) for the network %s", len(nodes), len(shardingStructure), network)
 		}
 	}
 
 	return shards, shardingStructure, nil
 }
 
-func generateShardType(node string, shardID uint32, shardingStructure []goSDK_sharding.RPCRoutes) Shard {
-	shard := Shard{Node: node}
-	rpcClient, _, err := commonRPC.NewRPCClient(node, shardID, shardingStructure, nil)
+func generateShardType(nodes []string, shardID uint32, shardingStructure []goSDK_sharding.RPCRoutes, selection string) Shard {
+	shard := Shard{Nodes: nodes}
+	if len(nodes) > 0 {
+		shard.Node = nodes[0]
+	}
+
+	if len(nodes) > 1 {
+		shard.Pool = rpc.NewNodePool(nodes, selection)
+		return shard
+	}
+
+	rpcClient, _, err := commonRPC.NewRPCClient(shard.Node, shardID, shardingStructure, nil)
 	if err == nil {
 		shard.RPCClient = rpcClient
 	}
 
 	return shard
 }
+
+// cachedRPCClient - returns the rpc client for a shard if it has already been resolved
+func (network *Network) cachedRPCClient(shardID uint32) (*goSDK_RPC.HTTPMessenger, bool, error) {
+	shard, ok := network.GetShard(shardID)
+	if !ok {
+		return nil, false, nil
+	}
+
+	if shard.Pool != nil {
+		node, err := shard.Pool.Select()
+		if err != nil {
+			return nil, true, err
+		}
+		return goSDK_RPC.NewHTTPHandler(node), true, nil
+	}
+
+	if shard.RPCClient != nil {
+		return shard.RPCClient, true, nil
+	}
+
+	return nil, false, nil
+}
+
+func (network *Network) resolveClient(node string, shardID uint32) (*rpc.Client, error) {
+	shardNode, shardingStructure, err := commonRPC.ResolveShardNode(node, shardID, network.resolveShardingStructure(node), &network.Retry)
+	if err != nil {
+		return nil, err
+	}
+	network.SetShardingStructure(shardingStructure)
+
+	if shardNode == "" {
+		return nil, fmt.Errorf("can't find a node for shard %d on the %s network", shardID, network.Name)
+	}
+
+	return rpc.NewClientWithRetry(shardNode, &network.Retry), nil
+}
+
+// resolveShardingStructure - returns the cached sharding structure to resolve shard nodes with
+// Local nodes don't need a sharding structure, if the structure can't be fetched the known structure is returned and the lookup is left to the caller
+func (network *Network) resolveShardingStructure(node string) []goSDK_sharding.RPCRoutes {
+	if utils.IsLocalNode(node) {
+		return network.GetShardingStructure()
+	}
+
+	shardingStructure, err := network.ShardingStructureWithContext(context.Background())
+	if err != nil {
+		return network.GetShardingStructure()
+	}
+
+	return shardingStructure
+}
+
+// applyShardingChange - called by the sharding structure cache whenever it detects a changed structure
+func (network *Network) applyShardingChange(change sharding.Change) {
+	network.mutex.Lock()
+	network.ShardingStructure = change.Current
+	network.ShardCount = len(change.Current)
+	for shardID, shard := range network.Shards {
+		if shard.Pool == nil {
+			shard.RPCClient = nil
+			shard.Client = nil
+			network.Shards[shardID] = shard
+		}
+	}
+	network.mutex.Unlock()
+
+	if change.ShardCountChanged() {
+		network.Log().Log(logging.WarnLevel, "shard count changed", logging.F("previous", len(change.Previous)), logging.F("current", len(change.Current)))
+	} else {
+		network.Log().Log(logging.InfoLevel, "sharding structure changed")
+	}
+}
+
+// shardLock - returns the lock serializing the client creation for a given shard
+func (network *Network) shardLock(shardID uint32) *sync.Mutex {
+	network.mutex.Lock()
+	defer network.mutex.Unlock()
+
+	if network.shardLocks == nil {
+		network.shardLocks = make(map[uint32]*sync.Mutex)
+	}
+
+	lock, ok := network.shardLocks[shardID]
+	if !ok {
+		lock = &sync.Mutex{}
+		network.shardLocks[shardID] = lock
+	}
+
+	return lock
+}
+
+// updateShard - applies fn to the stored configuration of a given shard
+func (network *Network) updateShard(shardID uint32, fn func(shard *Shard)) {
+	network.mutex.Lock()
+	defer network.mutex.Unlock()
+
+	shard := network.Shards[shardID]
+	fn(&shard)
+	network.storeShard(shardID, shard)
+}
+
+// storeShard has to be called while holding the network mutex
+func (network *Network) storeShard(shardID uint32, shard Shard) {
+	if network.Shards == nil {
+		network.Shards = make(map[uint32]Shard)
+	}
+
+	network.Shards[shardID] = shard
+}
+
+func (network *Network) unknownNodeError(shardID uint32) error {
+	return fmt.Errorf("can't generate a node address for shard %d: %w: %s - register the network using utils.RegisterNetwork or configure the shard nodes", shardID, utils.ErrUnknownNetwork, network.Name)
+}
+
+func (shard Shard) selectNode() string {
+	if shard.Pool != nil {
+		if node, err := shard.Pool.Select(); err == nil {
+			return node
+		}
+	}
+
+	return shard.Node
+}
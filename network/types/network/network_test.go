@@ -0,0 +1,143 @@
+package network_test
+
+import (
+	"sync"
+	"testing"
+
+	commonTypes "github.com/harmony-one/go-lib/network/types/common"
+	"github.com/harmony-one/go-lib/network/types/network"
+	"github.com/harmony-one/go-lib/network/utils"
+	"github.com/harmony-one/go-lib/rpc"
+	"github.com/harmony-one/go-lib/rpc/mock"
+	goSDK_common "github.com/harmony-one/go-sdk/pkg/common"
+	goSDK_RPC "github.com/harmony-one/go-sdk/pkg/rpc"
+	goSDK_sharding "github.com/harmony-one/go-sdk/pkg/sharding"
+)
+
+const (
+	shardCount = 2
+	goroutines = 50
+)
+
+type resolved struct {
+	node      string
+	rpcClient *goSDK_RPC.HTTPMessenger
+	client    *rpc.Client
+}
+
+func TestConcurrentAccess(t *testing.T) {
+	node := mock.NewNode()
+	defer node.Close()
+
+	node.SetEpoch(3)
+
+	routes := []goSDK_sharding.RPCRoutes{}
+	for shardID := 0; shardID < shardCount; shardID++ {
+		routes = append(routes, goSDK_sharding.RPCRoutes{HTTP: node.URL, ShardID: shardID})
+	}
+	node.SetShardingStructure(routes)
+
+	// every shard of the network is served by the same mock node, the node addresses are generated using the registered template
+	if err := utils.RegisterNetwork(utils.NetworkDefinition{Name: "mocknet", NodeTemplate: node.URL, ChainID: &goSDK_common.Chain.TestNet}); err != nil {
+		t.Fatalf("failed to register the mock network: %v", err)
+	}
+
+	net := &network.Network{Name: "mocknet", Mode: "api", Retry: commonTypes.Retry{Attempts: 1}}
+
+	results := make([][shardCount]resolved, goroutines)
+
+	var waitGroup sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		waitGroup.Add(1)
+		go func(i int) {
+			defer waitGroup.Done()
+
+			net.SetShardingStructure(routes)
+
+			for shardID := uint32(0); shardID < shardCount; shardID++ {
+				result := &results[i][shardID]
+				result.node = net.NodeAddress(shardID)
+
+				rpcClient, err := net.RPCClient(shardID)
+				if err != nil {
+					t.Errorf("RPCClient(%d) failed: %v", shardID, err)
+				}
+				result.rpcClient = rpcClient
+
+				client, err := net.Client(shardID)
+				if err != nil {
+					t.Errorf("Client(%d) failed: %v", shardID, err)
+				}
+				result.client = client
+
+				epoch, err := net.CurrentEpoch(shardID)
+				if err != nil || epoch != 3 {
+					t.Errorf("CurrentEpoch(%d) returned %d (error: %v), expected 3", shardID, epoch, err)
+				}
+			}
+		}(i)
+	}
+	waitGroup.Wait()
+
+	for shardID := uint32(0); shardID < shardCount; shardID++ {
+		shard, ok := net.GetShard(shardID)
+		if !ok || shard.Client == nil || shard.RPCClient == nil {
+			t.Fatalf("expected the clients for shard %d to be cached, got %+v", shardID, shard)
+		}
+
+		for i := range results {
+			result := results[i][shardID]
+			if result.node != node.URL {
+				t.Errorf("expected node %s for shard %d, got %s", node.URL, shardID, result.node)
+			}
+
+			if result.client != shard.Client {
+				t.Errorf("expected a single client for shard %d, goroutine %d got a different one", shardID, i)
+			}
+
+			if result.rpcClient != shard.RPCClient {
+				t.Errorf("expected a single rpc client for shard %d, goroutine %d got a different one", shardID, i)
+			}
+		}
+	}
+
+	if shardingStructure := net.GetShardingStructure(); len(shardingStructure) != shardCount {
+		t.Errorf("expected a sharding structure with %d shards, got %d", shardCount, len(shardingStructure))
+	}
+}
+
+func TestConcurrentPoolClient(t *testing.T) {
+	mockNetwork := mock.NewNetwork(shardCount)
+	defer mockNetwork.Close()
+
+	net := &network.Network{Name: "mocknet-pool", Retry: commonTypes.Retry{Attempts: 1}}
+	net.SetShardNodes(0, []string{mockNetwork.Node(0).URL, mockNetwork.Node(1).URL})
+
+	clients := make([]*rpc.Client, goroutines)
+
+	var waitGroup sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		waitGroup.Add(1)
+		go func(i int) {
+			defer waitGroup.Done()
+
+			client, err := net.Client(0)
+			if err != nil {
+				t.Errorf("Client(0) failed: %v", err)
+			}
+			clients[i] = client
+
+			if node := net.NodeAddress(0); node != mockNetwork.Node(0).URL && node != mockNetwork.Node(1).URL {
+				t.Errorf("expected one of the pool's nodes, got %s", node)
+			}
+		}(i)
+	}
+	waitGroup.Wait()
+
+	shard, _ := net.GetShard(0)
+	for i, client := range clients {
+		if client == nil || client != shard.Client {
+			t.Errorf("expected a single pooled client, goroutine %d got a different one", i)
+		}
+	}
+}
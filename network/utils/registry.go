@@ -0,0 +1,201 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/harmony-one/go-sdk/pkg/common"
+)
+
+// ShardPlaceholder - placeholder in node templates that gets replaced with the shard id
+const ShardPlaceholder = "{shard}"
+
+var (
+	// ErrUnknownNetwork is returned when a network name doesn't match any registered network or alias
+	ErrUnknownNetwork = errors.New("unknown network")
+
+	registry = &networkRegistry{networks: make(map[string]NetworkDefinition), aliases: make(map[string]string)}
+)
+
+// NetworkDefinition - describes a network: its aliases, the endpoint template used to generate shard nodes and its chain ids
+type NetworkDefinition struct {
+	Name         string
+	Aliases      []string
+	NodeTemplate string // NodeTemplate - node url for a given shard, ShardPlaceholder is replaced with the shard id, e.g. https://api.s{shard}.t.hmny.io
+	ChainID      *common.ChainID
+	EthChainID   *big.Int // EthChainID - eth compatible chain id of shard 0, the chain id of shard n is EthChainID + n
+
+	LockPeriodInEpochs int // LockPeriodInEpochs - number of epochs undelegated tokens stay locked, 0 means they're released at the end of the epoch they were undelegated in
+}
+
+type networkRegistry struct {
+	mutex    sync.RWMutex
+	networks map[string]NetworkDefinition
+	aliases  map[string]string
+}
+
+func init() {
+	// Lock periods as configured by harmony's chain configs (internal/params/config.go), testnet uses the quick unlock (LockPeriodInEpochV2)
+	builtin := []NetworkDefinition{
+		{Name: "localnet", Aliases: []string{"local"}, NodeTemplate: "http://localhost:950{shard}", ChainID: &common.Chain.TestNet, EthChainID: big.NewInt(1666700000), LockPeriodInEpochs: 7},
+		{Name: "devnet", Aliases: []string{"dev", "pga"}, NodeTemplate: "https://api.s{shard}.pga.hmny.io", ChainID: &common.Chain.PartnerNet, EthChainID: big.NewInt(1666900000), LockPeriodInEpochs: 7},
+		{Name: "pangaea", Aliases: []string{"staking", "openstaking", "os", "ostn"}, NodeTemplate: "https://api.s{shard}.os.hmny.io", ChainID: &common.Chain.PangaeaNet, EthChainID: big.NewInt(1666800000), LockPeriodInEpochs: 7},
+		{Name: "partner", Aliases: []string{"partnernet", "pstn"}, NodeTemplate: "https://api.s{shard}.ps.hmny.io", ChainID: &common.Chain.PartnerNet, EthChainID: big.NewInt(1666900000), LockPeriodInEpochs: 7},
+		{Name: "stressnet", Aliases: []string{"stress", "stresstest", "stn"}, NodeTemplate: "https://api.s{shard}.stn.hmny.io", ChainID: &common.Chain.StressNet, EthChainID: big.NewInt(1667000000), LockPeriodInEpochs: 7},
+		{Name: "testnet", Aliases: []string{"p", "b"}, NodeTemplate: "https://api.s{shard}.b.hmny.io", ChainID: &common.Chain.TestNet, EthChainID: big.NewInt(1666700000), LockPeriodInEpochs: 0},
+		{Name: "dryrun", Aliases: []string{"dry"}, NodeTemplate: "https://api.s{shard}.dry.hmny.io", ChainID: &common.Chain.MainNet, EthChainID: big.NewInt(1666600000), LockPeriodInEpochs: 7},
+		{Name: "mainnet", Aliases: []string{"main", "t"}, NodeTemplate: "https://api.s{shard}.t.hmny.io", ChainID: &common.Chain.MainNet, EthChainID: big.NewInt(1666600000), LockPeriodInEpochs: 7},
+	}
+
+	for _, definition := range builtin {
+		if err := RegisterNetwork(definition); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// RegisterNetwork - registers a network (or replaces a previously registered network with the same name)
+// An error is returned if the name or one of the aliases is already used by another network
+func RegisterNetwork(definition NetworkDefinition) error {
+	name := strings.ToLower(strings.TrimSpace(definition.Name))
+	if name == "" {
+		return errors.New("a network name is required")
+	}
+
+	if definition.NodeTemplate == "" {
+		return fmt.Errorf("a node template is required for the network %s", name)
+	}
+
+	if definition.ChainID == nil {
+		return fmt.Errorf("a chain id is required for the network %s", name)
+	}
+
+	keys := []string{name}
+	for _, alias := range definition.Aliases {
+		if alias = strings.ToLower(strings.TrimSpace(alias)); alias != "" {
+			keys = append(keys, alias)
+		}
+	}
+
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	for _, key := range keys {
+		if existing, ok := registry.aliases[key]; ok && existing != name {
+			return fmt.Errorf("%s is already used by the network %s", key, existing)
+		}
+	}
+
+	if existing, ok := registry.networks[name]; ok {
+		for _, alias := range existing.Aliases {
+			delete(registry.aliases, strings.ToLower(alias))
+		}
+	}
+
+	definition.Name = name
+	definition.Aliases = keys[1:]
+	registry.networks[name] = definition
+	for _, key := range keys {
+		registry.aliases[key] = name
+	}
+
+	return nil
+}
+
+// UnregisterNetwork - removes a network and its aliases from the registry
+func UnregisterNetwork(name string) {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	name = registry.aliases[strings.ToLower(name)]
+	definition, ok := registry.networks[name]
+	if !ok {
+		return
+	}
+
+	delete(registry.networks, name)
+	delete(registry.aliases, name)
+	for _, alias := range definition.Aliases {
+		delete(registry.aliases, alias)
+	}
+}
+
+// LookupNetwork - looks up a network using its name or one of its aliases
+func LookupNetwork(network string) (NetworkDefinition, error) {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+
+	name, ok := registry.aliases[strings.ToLower(strings.TrimSpace(network))]
+	if !ok {
+		return NetworkDefinition{}, fmt.Errorf("%w: %s", ErrUnknownNetwork, network)
+	}
+
+	return registry.networks[name], nil
+}
+
+// Networks - returns all registered networks sorted by name
+func Networks() []NetworkDefinition {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+
+	definitions := []NetworkDefinition{}
+	for _, definition := range registry.networks {
+		definitions = append(definitions, definition)
+	}
+	sort.Slice(definitions, func(i, j int) bool { return definitions[i].Name < definitions[j].Name })
+
+	return definitions
+}
+
+// NodeAddress - generates the node address for a given shard using the network's node template
+func (definition NetworkDefinition) NodeAddress(shardID uint32) string {
+	return strings.Replace(definition.NodeTemplate, ShardPlaceholder, fmt.Sprintf("%d", shardID), -1)
+}
+
+// ShardEthChainID - returns the eth compatible chain id for a given shard, nil if the network doesn't have an eth chain id
+func (definition NetworkDefinition) ShardEthChainID(shardID uint32) *big.Int {
+	if definition.EthChainID == nil {
+		return nil
+	}
+
+	return new(big.Int).Add(definition.EthChainID, big.NewInt(int64(shardID)))
+}
+
+// LookupNodeAddress - generates a node address for a given network and shard, returns ErrUnknownNetwork for unregistered networks
+func LookupNodeAddress(network string, shardID uint32) (string, error) {
+	definition, err := LookupNetwork(network)
+	if err != nil {
+		return "", err
+	}
+
+	return definition.NodeAddress(shardID), nil
+}
+
+// LookupLockPeriod - returns the number of epochs undelegated tokens stay locked on a given network, returns ErrUnknownNetwork for unregistered networks
+func LookupLockPeriod(network string) (int, error) {
+	definition, err := LookupNetwork(network)
+	if err != nil {
+		return 0, err
+	}
+
+	return definition.LockPeriodInEpochs, nil
+}
+
+// IdentifyEthChainID - identifies the eth compatible chain id for a given network name and shard
+func IdentifyEthChainID(network string, shardID uint32) (*big.Int, error) {
+	definition, err := LookupNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+
+	chainID := definition.ShardEthChainID(shardID)
+	if chainID == nil {
+		return nil, fmt.Errorf("the network %s doesn't have an eth chain id", definition.Name)
+	}
+
+	return chainID, nil
+}
@@ -1,7 +1,6 @@
 package utils
 
 import (
-	"fmt"
 	"net"
 	"strings"
 
@@ -17,15 +16,14 @@ func IsLocalNode(node string) bool {
 	return isLocalNode
 }
 
-// IdentifyNetworkChainID - identifies a chain id given a network name
+// IdentifyNetworkChainID - identifies a chain id given a network name, returns ErrUnknownNetwork for unregistered networks
 func IdentifyNetworkChainID(network string) (chain *common.ChainID, err error) {
-	network = NormalizedNetworkName(network)
-
-	if network == "dryrun" {
-		return &common.Chain.MainNet, nil
+	definition, err := LookupNetwork(network)
+	if err != nil {
+		return nil, err
 	}
 
-	return common.StringToChainID(network)
+	return definition.ChainID, nil
 }
 
 // GenerateNodeAddress - generates a node address given a network, mode and a shardID
@@ -39,55 +37,19 @@ func GenerateNodeAddress(network string, mode string, shardID uint32) (node stri
 	return node
 }
 
-// NormalizedNetworkName - return a normalized network name
+// NormalizedNetworkName - return a normalized network name, an empty string is returned for unregistered networks
 func NormalizedNetworkName(network string) string {
-	switch strings.ToLower(network) {
-	case "local", "localnet":
-		return "localnet"
-	case "dev", "devnet", "pga":
-		return "devnet"
-	case "staking", "openstaking", "os", "ostn", "pangaea":
-		return "pangaea"
-	case "partner", "partnernet", "pstn":
-		return "partner"
-	case "stress", "stresstest", "stn", "stressnet":
-		return "stressnet"
-	case "testnet", "p", "b":
-		return "testnet"
-	case "dryrun", "dry":
-		return "dryrun"
-	case "mainnet", "main", "t":
-		return "mainnet"
-	default:
+	definition, err := LookupNetwork(network)
+	if err != nil {
 		return ""
 	}
+
+	return definition.Name
 }
 
-// ToNodeAddress - generates a node address based on a given network name
+// ToNodeAddress - generates a node address based on a given network name, an empty string is returned for unregistered networks (see LookupNodeAddress)
 func ToNodeAddress(network string, shardID uint32) (node string) {
-	network = NormalizedNetworkName(network)
-
-	switch network {
-	case "localnet":
-		node = fmt.Sprintf("http://localhost:950%d", shardID)
-	case "devnet":
-		node = fmt.Sprintf("https://api.s%d.pga.hmny.io", shardID)
-	case "pangaea":
-		node = fmt.Sprintf("https://api.s%d.os.hmny.io", shardID)
-	case "partner":
-		node = fmt.Sprintf("https://api.s%d.ps.hmny.io", shardID)
-	case "stressnet":
-		node = fmt.Sprintf("https://api.s%d.stn.hmny.io", shardID)
-	case "testnet":
-		node = fmt.Sprintf("https://api.s%d.b.hmny.io", shardID)
-	case "dryrun":
-		node = fmt.Sprintf("https://api.s%d.dry.hmny.io", shardID)
-	case "mainnet":
-		node = fmt.Sprintf("https://api.s%d.t.hmny.io", shardID)
-	default:
-		node = fmt.Sprintf("http://localhost:950%d", shardID)
-	}
-
+	node, _ = LookupNodeAddress(network, shardID)
 	return node
 }
 
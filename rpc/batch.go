@@ -0,0 +1,212 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	goSdkCommon "github.com/harmony-one/go-sdk/pkg/common"
+)
+
+// DefaultBatchSize - maximum number of calls sent in a single HTTP request, larger batches are split into multiple requests
+var DefaultBatchSize = 100
+
+// ErrMissingBatchResponse is returned for a batch call the node didn't send a response for
+var ErrMissingBatchResponse = errors.New("no response received for the batch call")
+
+// BatchCall - a single JSON-RPC call in a batch
+type BatchCall struct {
+	Method string
+	Params []interface{}
+}
+
+// BatchResult - the result of a single JSON-RPC call in a batch, either Result or Error is set
+type BatchResult struct {
+	Method string
+	Result json.RawMessage
+	Error  error
+}
+
+// Batch - collects JSON-RPC calls and executes them using as few HTTP requests as possible (JSON-RPC 2.0 batches)
+type Batch struct {
+	Client *Client
+	Size   int // Size - maximum number of calls per HTTP request, defaults to DefaultBatchSize
+	calls  []BatchCall
+}
+
+type batchRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	ID      uint64        `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+type batchResponse struct {
+	ID     uint64          `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *RPCError       `json:"error,omitempty"`
+}
+
+// NewBatch - creates a new, empty batch for the client
+func (client *Client) NewBatch() *Batch {
+	return &Batch{Client: client}
+}
+
+// Add - adds a call to the batch and returns its index in the results
+func (batch *Batch) Add(method string, params []interface{}) int {
+	if params == nil {
+		params = []interface{}{}
+	}
+
+	batch.calls = append(batch.calls, BatchCall{Method: method, Params: params})
+
+	return len(batch.calls) - 1
+}
+
+// Len - returns the number of calls in the batch
+func (batch *Batch) Len() int {
+	return len(batch.calls)
+}
+
+// Execute - executes all calls and returns the results in the order the calls were added
+// The returned error is only set if a whole HTTP request failed, errors for individual calls are reported in their results
+func (batch *Batch) Execute(ctx context.Context) ([]BatchResult, error) {
+	return batch.Client.BatchRequest(ctx, batch.calls, batch.Size)
+}
+
+// BatchRequest - executes the given calls using JSON-RPC 2.0 batches of at most size calls (0 means DefaultBatchSize)
+// Results are matched to the calls by id and returned in the order of the calls
+// Batches containing a tx submission (see IsSubmission) are sent exactly once to a single node, like single submissions
+func (client *Client) BatchRequest(ctx context.Context, calls []BatchCall, size int) ([]BatchResult, error) {
+	if size <= 0 {
+		size = DefaultBatchSize
+	}
+
+	results := make([]BatchResult, 0, len(calls))
+	for start := 0; start < len(calls); start += size {
+		end := start + size
+		if end > len(calls) {
+			end = len(calls)
+		}
+
+		var chunk []BatchResult
+		var err error
+		if containsSubmission(calls[start:end]) {
+			chunk, err = client.batchRequest(ctx, calls[start:end], true)
+		} else {
+			err = ExecuteWithRetry(ctx, client.Retry, func(ctx context.Context) (err error) {
+				chunk, err = client.batchRequest(ctx, calls[start:end], false)
+				return err
+			})
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, chunk...)
+	}
+
+	return results, nil
+}
+
+// batchRequest sends a single batch, once disables the pool's failover (see NodePool.DoOnce)
+func (client *Client) batchRequest(ctx context.Context, calls []BatchCall, once bool) ([]BatchResult, error) {
+	requests := make([]batchRequest, len(calls))
+	indexes := make(map[uint64]int, len(calls))
+
+	for index, call := range calls {
+		params := call.Params
+		if params == nil {
+			params = []interface{}{}
+		}
+
+		id := atomic.AddUint64(&requestID, 1)
+		indexes[id] = index
+		requests[index] = batchRequest{JSONRPC: goSdkCommon.JSONRPCVersion, ID: id, Method: call.Method, Params: params}
+	}
+
+	requestBody, err := json.Marshal(requests)
+	if err != nil {
+		return nil, err
+	}
+
+	var body []byte
+	if client.Pool != nil {
+		post := func(ctx context.Context, node string) ([]byte, error) {
+			return client.post(ctx, node, requestBody)
+		}
+
+		if once {
+			body, err = client.Pool.DoOnce(ctx, post)
+		} else {
+			body, err = client.Pool.Do(ctx, post)
+		}
+	} else {
+		body, err = client.post(ctx, client.Node, requestBody)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Nodes that can't process a batch respond with a single error object instead of an array
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] != '[' {
+		if rpcError := parseRPCError(body); rpcError != nil {
+			return nil, *rpcError
+		}
+		return nil, fmt.Errorf("unexpected batch response: %s", string(trimmed))
+	}
+
+	responses := []batchResponse{}
+	if err := json.Unmarshal(body, &responses); err != nil {
+		return nil, err
+	}
+
+	results := make([]BatchResult, len(calls))
+	received := make([]bool, len(calls))
+	for _, response := range responses {
+		index, ok := indexes[response.ID]
+		if !ok {
+			continue
+		}
+
+		received[index] = true
+		results[index] = BatchResult{Method: calls[index].Method, Result: response.Result}
+		if response.Error != nil {
+			results[index].Error = *response.Error
+		}
+	}
+
+	for index := range results {
+		if !received[index] {
+			results[index] = BatchResult{Method: calls[index].Method, Error: ErrMissingBatchResponse}
+		}
+	}
+
+	return results, nil
+}
+
+func containsSubmission(calls []BatchCall) bool {
+	for _, call := range calls {
+		if IsSubmission(call.Method) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Decode - decodes the result of a call into value, returns the call error if the call failed
+func (result BatchResult) Decode(value interface{}) error {
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if len(result.Result) == 0 {
+		return fmt.Errorf("empty result for %s", result.Method)
+	}
+
+	return json.Unmarshal(result.Result, value)
+}
@@ -0,0 +1,54 @@
+package rpc_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	commonTypes "github.com/harmony-one/go-lib/network/types/common"
+	"github.com/harmony-one/go-lib/rpc"
+	"github.com/harmony-one/go-lib/rpc/mock"
+	goSdkRPC "github.com/harmony-one/go-sdk/pkg/rpc"
+)
+
+func TestBatchRetriesTransientErrors(t *testing.T) {
+	node := mock.NewNode()
+	defer node.Close()
+
+	node.FailNextHTTP("", 1, http.StatusServiceUnavailable)
+
+	batch := rpc.NewClientWithRetry(node.URL, &commonTypes.Retry{Attempts: 3}).NewBatch()
+	batch.Add(goSdkRPC.Method.BlockNumber, nil)
+
+	results, err := batch.Execute(context.Background())
+	if err != nil {
+		t.Fatalf("expected the batch to succeed after retrying, got %v", err)
+	}
+
+	if len(results) != 1 || results[0].Error != nil {
+		t.Errorf("unexpected results: %+v", results)
+	}
+
+	if calls := node.Calls("blockNumber"); calls != 2 {
+		t.Errorf("expected 2 calls, got %d", calls)
+	}
+}
+
+func TestBatchWithSubmissionIsSentOnce(t *testing.T) {
+	node := mock.NewNode()
+	defer node.Close()
+
+	node.FailNextHTTP("", 1, http.StatusServiceUnavailable)
+
+	batch := rpc.NewClientWithRetry(node.URL, &commonTypes.Retry{Attempts: 3}).NewBatch()
+	batch.Add(goSdkRPC.Method.BlockNumber, nil)
+	batch.Add(goSdkRPC.Method.SendRawTransaction, []interface{}{"0x00"})
+
+	if _, err := batch.Execute(context.Background()); err == nil {
+		t.Fatal("expected the injected error")
+	}
+
+	if calls := node.Calls(""); calls != 1 {
+		t.Errorf("batches containing a submission shouldn't be resent, got %d calls", calls)
+	}
+}
@@ -0,0 +1,245 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// BlockByNumberMethod - RPC method used to fetch full blocks, the v2 method supports fetching the block signers
+const BlockByNumberMethod = "hmyv2_getBlockByNumber"
+
+// BlockOptions - controls what is included when fetching a block
+type BlockOptions struct {
+	FullTx      bool // FullTx - include the full transactions instead of only their hashes
+	WithSigners bool // WithSigners - include the bech32 addresses of the validators that signed the block
+}
+
+// Block - a block as returned by the hmyv2_getBlockByNumber RPC method
+type Block struct {
+	Number              uint64                    `json:"number" yaml:"number"`
+	ViewID              uint64                    `json:"viewID" yaml:"viewID"`
+	Epoch               uint64                    `json:"epoch" yaml:"epoch"`
+	ShardID             uint32                    `json:"-" yaml:"-"` // ShardID - not part of the RPC response, set by the caller / scanner
+	Hash                string                    `json:"hash" yaml:"hash"`
+	ParentHash          string                    `json:"parentHash" yaml:"parentHash"`
+	Nonce               uint64                    `json:"nonce" yaml:"nonce"`
+	MixHash             string                    `json:"mixHash" yaml:"mixHash"`
+	LogsBloom           string                    `json:"logsBloom,omitempty" yaml:"logsBloom,omitempty"`
+	StateRoot           string                    `json:"stateRoot" yaml:"stateRoot"`
+	Miner               string                    `json:"miner" yaml:"miner"`
+	Difficulty          uint64                    `json:"difficulty" yaml:"difficulty"`
+	ExtraData           string                    `json:"extraData" yaml:"extraData"`
+	Size                uint64                    `json:"size" yaml:"size"`
+	GasLimit            uint64                    `json:"gasLimit" yaml:"gasLimit"`
+	GasUsed             uint64                    `json:"gasUsed" yaml:"gasUsed"`
+	RawTimestamp        uint64                    `json:"timestamp" yaml:"timestamp"`
+	Timestamp           time.Time                 `json:"-" yaml:"-"`
+	TransactionsRoot    string                    `json:"transactionsRoot" yaml:"transactionsRoot"`
+	ReceiptsRoot        string                    `json:"receiptsRoot" yaml:"receiptsRoot"`
+	Uncles              []string                  `json:"uncles" yaml:"uncles"`
+	Transactions        []BlockTransaction        `json:"transactions" yaml:"transactions"`
+	StakingTransactions []BlockStakingTransaction `json:"stakingTransactions" yaml:"stakingTransactions"`
+	Signers             []string                  `json:"signers,omitempty" yaml:"signers,omitempty"`
+}
+
+// BlockTransaction - a regular transaction included in a block, only Hash is set if the block was fetched without full transactions
+type BlockTransaction struct {
+	Hash             string   `json:"hash" yaml:"hash"`
+	BlockHash        string   `json:"blockHash" yaml:"blockHash"`
+	BlockNumber      uint64   `json:"blockNumber" yaml:"blockNumber"`
+	From             string   `json:"from" yaml:"from"`
+	To               string   `json:"to" yaml:"to"`
+	Timestamp        uint64   `json:"timestamp" yaml:"timestamp"`
+	Gas              uint64   `json:"gas" yaml:"gas"`
+	GasPrice         *big.Int `json:"gasPrice" yaml:"gasPrice"`
+	Input            string   `json:"input" yaml:"input"`
+	Nonce            uint64   `json:"nonce" yaml:"nonce"`
+	TransactionIndex uint64   `json:"transactionIndex" yaml:"transactionIndex"`
+	Value            *big.Int `json:"value" yaml:"value"`
+	ShardID          uint32   `json:"shardID" yaml:"shardID"`
+	ToShardID        uint32   `json:"toShardID" yaml:"toShardID"`
+}
+
+// BlockStakingTransaction - a staking transaction included in a block, only Hash is set if the block was fetched without full transactions
+type BlockStakingTransaction struct {
+	Hash             string          `json:"hash" yaml:"hash"`
+	BlockHash        string          `json:"blockHash" yaml:"blockHash"`
+	BlockNumber      uint64          `json:"blockNumber" yaml:"blockNumber"`
+	From             string          `json:"from" yaml:"from"`
+	Timestamp        uint64          `json:"timestamp" yaml:"timestamp"`
+	Gas              uint64          `json:"gas" yaml:"gas"`
+	GasPrice         *big.Int        `json:"gasPrice" yaml:"gasPrice"`
+	Nonce            uint64          `json:"nonce" yaml:"nonce"`
+	TransactionIndex uint64          `json:"transactionIndex" yaml:"transactionIndex"`
+	Type             string          `json:"type" yaml:"type"`
+	Msg              json.RawMessage `json:"msg" yaml:"msg"`
+}
+
+// UnmarshalJSON - accepts both a full transaction and a plain transaction hash
+func (tx *BlockTransaction) UnmarshalJSON(data []byte) error {
+	var hash string
+	if err := json.Unmarshal(data, &hash); err == nil {
+		*tx = BlockTransaction{Hash: hash}
+		return nil
+	}
+
+	type blockTransaction BlockTransaction
+	return json.Unmarshal(data, (*blockTransaction)(tx))
+}
+
+// UnmarshalJSON - accepts both a full staking transaction and a plain transaction hash
+func (tx *BlockStakingTransaction) UnmarshalJSON(data []byte) error {
+	var hash string
+	if err := json.Unmarshal(data, &hash); err == nil {
+		*tx = BlockStakingTransaction{Hash: hash}
+		return nil
+	}
+
+	type blockStakingTransaction BlockStakingTransaction
+	return json.Unmarshal(data, (*blockStakingTransaction)(tx))
+}
+
+// Initialize - initialize and convert values for a given Block struct
+func (block *Block) Initialize() error {
+	block.Timestamp = time.Unix(int64(block.RawTimestamp), 0).UTC()
+	return nil
+}
+
+// Header - returns the header of the block
+func (block *Block) Header() Header {
+	return Header{
+		ShardID:     block.ShardID,
+		Hash:        block.Hash,
+		BlockNumber: block.Number,
+		ViewID:      block.ViewID,
+		Epoch:       block.Epoch,
+	}
+}
+
+// TransactionCount - returns the number of regular and staking transactions in the block
+func (block *Block) TransactionCount() int {
+	return len(block.Transactions) + len(block.StakingTransactions)
+}
+
+// ContainsTransaction - checks if a regular or staking transaction with a given hash was included in the block
+func (block *Block) ContainsTransaction(hash string) bool {
+	for _, tx := range block.Transactions {
+		if strings.EqualFold(tx.Hash, hash) {
+			return true
+		}
+	}
+
+	for _, tx := range block.StakingTransactions {
+		if strings.EqualFold(tx.Hash, hash) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CalculateTPS - calculates the transactions per second across the given (ordered) blocks using their timestamps
+// The transactions of the first block are excluded since the time they were produced in isn't covered by the range
+func CalculateTPS(blocks []*Block) float64 {
+	if len(blocks) < 2 {
+		return 0
+	}
+
+	first := blocks[0]
+	last := blocks[len(blocks)-1]
+	duration := last.Timestamp.Sub(first.Timestamp).Seconds()
+	if duration <= 0 {
+		return 0
+	}
+
+	count := 0
+	for _, block := range blocks[1:] {
+		count += block.TransactionCount()
+	}
+
+	return float64(count) / duration
+}
+
+// GetBlockWithContext - retrieve a full block using a given context and client
+func GetBlockWithContext(ctx context.Context, client *Client, blockNumber uint64, options BlockOptions) (*Block, error) {
+	reply, err := client.RawRequest(ctx, BlockByNumberMethod, blockParams(blockNumber, options))
+	if err != nil {
+		return nil, err
+	}
+
+	response := struct {
+		Result *Block    `json:"result"`
+		Error  *RPCError `json:"error,omitempty"`
+	}{}
+	if err := json.Unmarshal(reply, &response); err != nil {
+		return nil, err
+	}
+
+	if response.Error != nil {
+		return nil, *response.Error
+	}
+
+	return initializeBlock(response.Result, blockNumber)
+}
+
+// GetBlocksWithContext - retrieve the full blocks from start to end (inclusive) using batched requests
+// The returned error is only set if a whole batch failed, errors for individual blocks are reported in their results
+func GetBlocksWithContext(ctx context.Context, client *Client, start uint64, end uint64, options BlockOptions) ([]ScannedBlock, error) {
+	if end < start {
+		return nil, fmt.Errorf("invalid block range %d - %d", start, end)
+	}
+
+	batch := client.NewBatch()
+	for blockNumber := start; blockNumber <= end; blockNumber++ {
+		batch.Add(BlockByNumberMethod, blockParams(blockNumber, options))
+	}
+
+	batchResults, err := batch.Execute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]ScannedBlock, len(batchResults))
+	for index, batchResult := range batchResults {
+		blockNumber := start + uint64(index)
+		results[index] = ScannedBlock{BlockNumber: blockNumber}
+
+		var block *Block
+		if err := batchResult.Decode(&block); err != nil {
+			results[index].Error = err
+			continue
+		}
+
+		results[index].Block, results[index].Error = initializeBlock(block, blockNumber)
+	}
+
+	return results, nil
+}
+
+func blockParams(blockNumber uint64, options BlockOptions) []interface{} {
+	return []interface{}{
+		blockNumber,
+		map[string]interface{}{
+			"fullTx":      options.FullTx,
+			"inclTx":      true,
+			"withSigners": options.WithSigners,
+			"inclStaking": true,
+		},
+	}
+}
+
+func initializeBlock(block *Block, blockNumber uint64) (*Block, error) {
+	if block == nil {
+		return nil, fmt.Errorf("block %d not found", blockNumber)
+	}
+
+	if err := block.Initialize(); err != nil {
+		return nil, err
+	}
+
+	return block, nil
+}
@@ -1,6 +1,7 @@
 package rpc
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -30,6 +31,13 @@ type BlockInfo struct {
 	Timestamp    time.Time `json:"-" yaml:"-"`
 }
 
+// BlockResult - the lookup result for a single block in a range, either Block or Error is set
+type BlockResult struct {
+	BlockNumber uint64
+	Block       BlockInfo
+	Error       error
+}
+
 // RPCGenericSingleHexResponse - wrapper for RPC calls returning a single result in a hex format
 type RPCGenericSingleHexResponse struct {
 	ID      string `json:"id" yaml:"id"`
@@ -39,11 +47,16 @@ type RPCGenericSingleHexResponse struct {
 
 // GetBlockByNumber - retrieve information for a specific block
 func GetBlockByNumber(blockNumber uint64, includeTransactions bool, node string) (BlockInfo, error) {
+	return GetBlockByNumberWithContext(context.Background(), NewClient(node), blockNumber, includeTransactions)
+}
+
+// GetBlockByNumberWithContext - retrieve information for a specific block using a given context and client
+func GetBlockByNumberWithContext(ctx context.Context, client *Client, blockNumber uint64, includeTransactions bool) (BlockInfo, error) {
 	response := BlockWrapper{}
 	result := BlockInfo{}
 	blockNum := fmt.Sprintf("0x%x", blockNumber)
 
-	bytes, err := goSdkRPC.RawRequest(goSdkRPC.Method.GetBlockByNumber, node, []interface{}{blockNum, includeTransactions})
+	bytes, err := client.RawRequest(ctx, goSdkRPC.Method.GetBlockByNumber, []interface{}{blockNum, includeTransactions})
 	if err != nil {
 		return result, err
 	}
@@ -63,11 +76,61 @@ func GetBlockByNumber(blockNumber uint64, includeTransactions bool, node string)
 	return result, nil
 }
 
+// GetBlockRangeWithContext - retrieve information for all blocks from start to end (inclusive) using batched requests
+// The returned error is only set if a whole batch failed, errors for individual blocks are reported in their results
+func GetBlockRangeWithContext(ctx context.Context, client *Client, start uint64, end uint64, includeTransactions bool) ([]BlockResult, error) {
+	if end < start {
+		return nil, fmt.Errorf("invalid block range %d - %d", start, end)
+	}
+
+	batch := client.NewBatch()
+	for blockNumber := start; blockNumber <= end; blockNumber++ {
+		batch.Add(goSdkRPC.Method.GetBlockByNumber, []interface{}{fmt.Sprintf("0x%x", blockNumber), includeTransactions})
+	}
+
+	batchResults, err := batch.Execute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]BlockResult, len(batchResults))
+	for index, batchResult := range batchResults {
+		blockNumber := start + uint64(index)
+		results[index] = BlockResult{BlockNumber: blockNumber}
+
+		var block *BlockInfo
+		if err := batchResult.Decode(&block); err != nil {
+			results[index].Error = err
+			continue
+		}
+
+		if block == nil {
+			results[index].Error = fmt.Errorf("block %d not found", blockNumber)
+			continue
+		}
+
+		block.BlockNumber = blockNumber
+		if err := block.Initialize(); err != nil {
+			results[index].Error = err
+			continue
+		}
+
+		results[index].Block = *block
+	}
+
+	return results, nil
+}
+
 // GetCurrentBlockNumber - get the current block number for a given node
 func GetCurrentBlockNumber(node string) (uint64, error) {
+	return GetCurrentBlockNumberWithContext(context.Background(), NewClient(node))
+}
+
+// GetCurrentBlockNumberWithContext - get the current block number using a given context and client
+func GetCurrentBlockNumberWithContext(ctx context.Context, client *Client) (uint64, error) {
 	response := RPCGenericSingleHexResponse{}
 
-	bytes, err := goSdkRPC.RawRequest(goSdkRPC.Method.BlockNumber, node, []interface{}{})
+	bytes, err := client.RawRequest(ctx, goSdkRPC.Method.BlockNumber, []interface{}{})
 	if err != nil {
 		return 0, err
 	}
@@ -79,11 +142,16 @@ func GetCurrentBlockNumber(node string) (uint64, error) {
 
 // GetTransactionCountByBlockNumber - get the transaction count for a given block
 func GetTransactionCountByBlockNumber(blockNumber uint64, node string) (uint64, error) {
+	return GetTransactionCountByBlockNumberWithContext(context.Background(), NewClient(node), blockNumber)
+}
+
+// GetTransactionCountByBlockNumberWithContext - get the transaction count for a given block using a given context and client
+func GetTransactionCountByBlockNumberWithContext(ctx context.Context, client *Client, blockNumber uint64) (uint64, error) {
 	response := RPCGenericSingleHexResponse{}
 
 	param := fmt.Sprintf("0x%x", blockNumber)
 
-	bytes, err := goSdkRPC.RawRequest(goSdkRPC.Method.GetBlockTransactionCountByNumber, node, []interface{}{param})
+	bytes, err := client.RawRequest(ctx, goSdkRPC.Method.GetBlockTransactionCountByNumber, []interface{}{param})
 	if err != nil {
 		return 0, err
 	}
@@ -0,0 +1,179 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+
+	commonTypes "github.com/harmony-one/go-lib/network/types/common"
+	goSdkCommon "github.com/harmony-one/go-sdk/pkg/common"
+	goSdkRPC "github.com/harmony-one/go-sdk/pkg/rpc"
+)
+
+var (
+	requestID uint64
+)
+
+// Client - context aware JSON-RPC client used by all go-lib RPC helpers
+type Client struct {
+	Node       string
+	Pool       *NodePool // Pool - if set, requests are load balanced across the pool's nodes and Node is ignored
+	HTTPClient *http.Client
+	Retry      *commonTypes.Retry
+}
+
+// NewClient - creates a new client for a given node using the DefaultRetry policy
+func NewClient(node string) *Client {
+	defaultRetry := DefaultRetry
+	return NewClientWithRetry(node, &defaultRetry)
+}
+
+// NewClientWithRetry - creates a new client for a given node using a specific retry policy, a nil retry policy makes a single attempt per request
+func NewClientWithRetry(node string, retry *commonTypes.Retry) *Client {
+	return &Client{
+		Node:       node,
+		HTTPClient: http.DefaultClient,
+		Retry:      retry,
+	}
+}
+
+// NewPoolClient - creates a new client load balancing / failing over across the nodes of a given pool using a specific retry policy
+func NewPoolClient(pool *NodePool, retry *commonTypes.Retry) *Client {
+	client := NewClientWithRetry("", retry)
+	client.Pool = pool
+
+	if nodes := pool.Nodes(); len(nodes) > 0 {
+		client.Node = nodes[0]
+	}
+
+	return client
+}
+
+// RawRequest - performs a JSON-RPC request and returns the raw response body, retrying transient errors according to the client's retry policy
+// Tx submissions (see IsSubmission) are sent exactly once to a single node, resending them could broadcast the same tx twice
+func (client *Client) RawRequest(ctx context.Context, method string, params []interface{}) (body []byte, err error) {
+	if IsSubmission(method) {
+		if client.Pool != nil {
+			return client.Pool.DoOnce(ctx, func(ctx context.Context, node string) ([]byte, error) {
+				return client.send(ctx, node, method, params)
+			})
+		}
+
+		return client.send(ctx, client.Node, method, params)
+	}
+
+	err = ExecuteWithRetry(ctx, client.Retry, func(ctx context.Context) error {
+		body, err = client.rawRequest(ctx, method, params)
+		return err
+	})
+
+	return body, err
+}
+
+func (client *Client) rawRequest(ctx context.Context, method string, params []interface{}) ([]byte, error) {
+	if client.Pool != nil {
+		return client.Pool.Do(ctx, func(ctx context.Context, node string) ([]byte, error) {
+			return client.send(ctx, node, method, params)
+		})
+	}
+
+	return client.send(ctx, client.Node, method, params)
+}
+
+func (client *Client) send(ctx context.Context, node string, method string, params []interface{}) ([]byte, error) {
+	if params == nil {
+		params = []interface{}{}
+	}
+
+	requestBody, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": goSdkCommon.JSONRPCVersion,
+		"id":      strconv.FormatUint(atomic.AddUint64(&requestID, 1), 10),
+		"method":  method,
+		"params":  params,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := client.post(ctx, node, requestBody)
+	if err != nil {
+		return nil, err
+	}
+
+	// Surface transient RPC errors (e.g. rate limiting) so that they can be retried
+	if rpcError := parseRPCError(body); rpcError != nil && IsRetryable(*rpcError) {
+		return nil, *rpcError
+	}
+
+	return body, nil
+}
+
+func (client *Client) post(ctx context.Context, node string, requestBody []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, node, bytes.NewReader(requestBody))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	httpClient := client.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, &HTTPStatusError{StatusCode: res.StatusCode}
+	}
+
+	return body, nil
+}
+
+// Request - performs a JSON-RPC request and returns the decoded reply, converting RPC errors to regular errors
+func (client *Client) Request(ctx context.Context, method string, params []interface{}) (goSdkRPC.Reply, error) {
+	bytes, err := client.RawRequest(ctx, method, params)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := goSdkRPC.Reply{}
+	if err := json.Unmarshal(bytes, &reply); err != nil {
+		return nil, err
+	}
+
+	if rpcError := parseRPCError(bytes); rpcError != nil {
+		return nil, *rpcError
+	}
+
+	return reply, nil
+}
+
+// SendRPC - implements go-sdk's rpc.T interface so the client can be used with go-sdk helpers
+func (client *Client) SendRPC(method string, params []interface{}) (goSdkRPC.Reply, error) {
+	return client.Request(context.Background(), method, params)
+}
+
+func parseRPCError(body []byte) *RPCError {
+	response := struct {
+		Error *RPCError `json:"error,omitempty"`
+	}{}
+
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil
+	}
+
+	return response.Error
+}
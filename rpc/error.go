@@ -1,7 +1,24 @@
 package rpc
 
+import "fmt"
+
 // RPCError - error returned from the RPC endpoint
 type RPCError struct {
 	Code    int    `json:"code,omitempty" yaml:"code,omitempty"`
 	Message string `json:"message,omitempty" yaml:"message,omitempty"`
 }
+
+// Error - implements the error interface
+func (rpcError RPCError) Error() string {
+	return fmt.Sprintf("%s (%d)", rpcError.Message, rpcError.Code)
+}
+
+// HTTPStatusError - returned when a node responds with a non 200 status code
+type HTTPStatusError struct {
+	StatusCode int
+}
+
+// Error - implements the error interface
+func (statusError *HTTPStatusError) Error() string {
+	return fmt.Sprintf("http status code not 200, received: %d", statusError.StatusCode)
+}
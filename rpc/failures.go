@@ -1,6 +1,7 @@
 package rpc
 
 import (
+	"context"
 	"encoding/json"
 
 	goSdkRPC "github.com/harmony-one/go-sdk/pkg/rpc"
@@ -23,13 +24,18 @@ type Failure struct {
 
 // AllFailures - get both transaction and staking failures at the same time
 func AllFailures(node string) (failures []Failure, err error) {
-	txFailures, err := TransactionFailures(node)
+	return AllFailuresWithContext(context.Background(), NewClient(node))
+}
+
+// AllFailuresWithContext - get both transaction and staking failures at the same time using a given context and client
+func AllFailuresWithContext(ctx context.Context, client *Client) (failures []Failure, err error) {
+	txFailures, err := TransactionFailuresWithContext(ctx, client)
 	if err != nil {
 		return failures, err
 	}
 	failures = append(failures, txFailures...)
 
-	stakingFailures, err := StakingFailures(node)
+	stakingFailures, err := StakingFailuresWithContext(ctx, client)
 	if err != nil {
 		return failures, err
 	}
@@ -40,10 +46,15 @@ func AllFailures(node string) (failures []Failure, err error) {
 
 // TransactionFailures - get the transaction failures from the given node
 func TransactionFailures(node string) ([]Failure, error) {
+	return TransactionFailuresWithContext(context.Background(), NewClient(node))
+}
+
+// TransactionFailuresWithContext - get the transaction failures using a given context and client
+func TransactionFailuresWithContext(ctx context.Context, client *Client) ([]Failure, error) {
 	response := FailureWrapper{}
 	failures := []Failure{}
 
-	bytes, err := goSdkRPC.RawRequest(goSdkRPC.Method.GetCurrentTransactionErrorSink, node, []interface{}{})
+	bytes, err := client.RawRequest(ctx, goSdkRPC.Method.GetCurrentTransactionErrorSink, []interface{}{})
 	if err != nil {
 		return failures, err
 	}
@@ -56,10 +67,15 @@ func TransactionFailures(node string) ([]Failure, error) {
 
 // StakingFailures - get the staking failures from the given node
 func StakingFailures(node string) ([]Failure, error) {
+	return StakingFailuresWithContext(context.Background(), NewClient(node))
+}
+
+// StakingFailuresWithContext - get the staking failures using a given context and client
+func StakingFailuresWithContext(ctx context.Context, client *Client) ([]Failure, error) {
 	response := FailureWrapper{}
 	failures := []Failure{}
 
-	bytes, err := goSdkRPC.RawRequest(goSdkRPC.Method.GetCurrentStakingErrorSink, node, []interface{}{})
+	bytes, err := client.RawRequest(ctx, goSdkRPC.Method.GetCurrentStakingErrorSink, []interface{}{})
 	if err != nil {
 		return failures, err
 	}
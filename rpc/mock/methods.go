@@ -0,0 +1,540 @@
+package mock
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	eth_hexutil "github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/harmony-one/go-lib/rpc"
+	"github.com/harmony-one/go-sdk/pkg/address"
+	"github.com/harmony-one/harmony/core/types"
+	hmyStaking "github.com/harmony-one/harmony/staking/types"
+)
+
+// ValidatorPageSize - number of validators returned per page by getAllValidatorInformation
+var ValidatorPageSize = 100
+
+func (node *Node) builtinHandler(method string, v2 bool) Handler {
+	switch method {
+	case "getBalance":
+		return node.withState(func(params []json.RawMessage) (interface{}, *Error) {
+			addr, err := stringParam(params, 0)
+			if err != nil {
+				return nil, err
+			}
+			return bigQuantity(node.state.balances[normalizeAddress(addr)], v2), nil
+		})
+	case "getTransactionCount":
+		return node.withState(func(params []json.RawMessage) (interface{}, *Error) {
+			addr, err := stringParam(params, 0)
+			if err != nil {
+				return nil, err
+			}
+			return quantity(node.state.nonces[normalizeAddress(addr)], v2), nil
+		})
+	case "blockNumber":
+		return node.withState(func(params []json.RawMessage) (interface{}, *Error) {
+			return quantity(node.state.blockNumber, v2), nil
+		})
+	case "getBlockByNumber":
+		return node.withState(func(params []json.RawMessage) (interface{}, *Error) {
+			blockNumber, err := node.blockNumberParam(params, 0)
+			if err != nil {
+				return nil, err
+			}
+			fullTx, withSigners := blockArgsParam(params, 1)
+			block, ok := node.state.blocks[blockNumber]
+			if !ok {
+				return nil, nil
+			}
+			rendered := node.renderBlock(block, fullTx, v2)
+			if withSigners {
+				rendered["signers"] = append([]string{}, block.Signers...)
+			}
+			return rendered, nil
+		})
+	case "getBlockTransactionCountByNumber":
+		return node.withState(func(params []json.RawMessage) (interface{}, *Error) {
+			blockNumber, err := node.blockNumberParam(params, 0)
+			if err != nil {
+				return nil, err
+			}
+			block, ok := node.state.blocks[blockNumber]
+			if !ok {
+				return nil, nil
+			}
+			return quantity(uint64(len(block.Transactions)), v2), nil
+		})
+	case "latestHeader":
+		return node.withState(func(params []json.RawMessage) (interface{}, *Error) {
+			block, ok := node.state.blocks[node.state.blockNumber]
+			if !ok {
+				block = Block{Number: node.state.blockNumber, Hash: hashOf(fmt.Sprintf("block-%d-%d", node.ShardID, node.state.blockNumber)), Timestamp: time.Now().UTC()}
+			}
+			return map[string]interface{}{
+				"blockHash":   block.Hash,
+				"blockNumber": block.Number,
+				"epoch":       node.state.epoch,
+				"shardID":     node.ShardID,
+				"timestamp":   block.Timestamp.Format(time.RFC3339),
+				"viewID":      block.Number,
+			}, nil
+		})
+	case "getNodeMetadata":
+		return node.withState(func(params []json.RawMessage) (interface{}, *Error) {
+			chainConfig := map[string]interface{}{}
+			if node.state.redelegationEpoch != nil {
+				chainConfig["redelegation-epoch"] = node.state.redelegationEpoch
+			}
+			return map[string]interface{}{
+				"shard-id":      node.ShardID,
+				"current-epoch": node.state.epoch,
+				"chain-config":  chainConfig,
+			}, nil
+		})
+	case "getShardingStructure":
+		return node.withState(func(params []json.RawMessage) (interface{}, *Error) {
+			routes := []map[string]interface{}{}
+			if len(node.state.shardingStructure) == 0 {
+				return append(routes, map[string]interface{}{"current": true, "http": node.URL, "shardID": node.ShardID, "ws": ""}), nil
+			}
+			for _, route := range node.state.shardingStructure {
+				routes = append(routes, map[string]interface{}{"current": uint32(route.ShardID) == node.ShardID, "http": route.HTTP, "shardID": route.ShardID, "ws": route.WS})
+			}
+			return routes, nil
+		})
+	case "sendRawTransaction":
+		return node.withState(func(params []json.RawMessage) (interface{}, *Error) {
+			return node.receiveTransaction(params, false)
+		})
+	case "sendRawStakingTransaction":
+		return node.withState(func(params []json.RawMessage) (interface{}, *Error) {
+			return node.receiveTransaction(params, true)
+		})
+	case "pendingTransactions":
+		return node.withState(func(params []json.RawMessage) (interface{}, *Error) {
+			pending := []interface{}{}
+			for _, tx := range node.state.pending {
+				pending = append(pending, map[string]interface{}{
+					"hash":      tx.Hash,
+					"from":      tx.From,
+					"to":        tx.To,
+					"nonce":     quantity(tx.Nonce, v2),
+					"shardID":   tx.ShardID,
+					"toShardID": tx.ToShardID,
+				})
+			}
+			return pending, nil
+		})
+	case "getTransactionReceipt":
+		return node.withState(func(params []json.RawMessage) (interface{}, *Error) {
+			hash, err := stringParam(params, 0)
+			if err != nil {
+				return nil, err
+			}
+			receipt, ok := node.state.receipts[hash]
+			if !ok {
+				return nil, nil
+			}
+			return renderReceipt(receipt, v2), nil
+		})
+	case "getCXReceiptByHash":
+		return node.withState(func(params []json.RawMessage) (interface{}, *Error) {
+			hash, err := stringParam(params, 0)
+			if err != nil {
+				return nil, err
+			}
+			receipt, ok := node.state.cxReceipts[hash]
+			if !ok {
+				return nil, nil
+			}
+			return renderCXReceipt(receipt, v2), nil
+		})
+	case "getCurrentTransactionErrorSink":
+		return node.withState(func(params []json.RawMessage) (interface{}, *Error) {
+			return append([]rpc.Failure{}, node.state.txFailures...), nil
+		})
+	case "getCurrentStakingErrorSink":
+		return node.withState(func(params []json.RawMessage) (interface{}, *Error) {
+			return append([]rpc.Failure{}, node.state.stakingFailures...), nil
+		})
+	case "getAllValidatorAddresses":
+		return node.withState(func(params []json.RawMessage) (interface{}, *Error) {
+			return node.sortedValidators(), nil
+		})
+	case "getElectedValidatorAddresses":
+		return node.withState(func(params []json.RawMessage) (interface{}, *Error) {
+			return append([]string{}, node.state.elected...), nil
+		})
+	case "getValidatorInformation":
+		return node.withState(func(params []json.RawMessage) (interface{}, *Error) {
+			addr, err := stringParam(params, 0)
+			if err != nil {
+				return nil, err
+			}
+			information, ok := node.state.validators[normalizeAddress(addr)]
+			if !ok {
+				return nil, &Error{Code: ErrorCodeServer, Message: "validator not found: " + addr}
+			}
+			return information, nil
+		})
+	case "getAllValidatorInformation", "getAllValidatorInformationByBlockNumber":
+		return node.withState(func(params []json.RawMessage) (interface{}, *Error) {
+			page, err := intParam(params, 0)
+			if err != nil {
+				return nil, err
+			}
+			addresses := node.sortedValidators()
+			if page >= 0 {
+				start, end := page*ValidatorPageSize, (page+1)*ValidatorPageSize
+				if start > len(addresses) {
+					start = len(addresses)
+				}
+				if end > len(addresses) {
+					end = len(addresses)
+				}
+				addresses = addresses[start:end]
+			}
+			information := []interface{}{}
+			for _, addr := range addresses {
+				information = append(information, node.state.validators[addr])
+			}
+			return information, nil
+		})
+	case "getDelegationsByDelegator", "getDelegationsByValidator":
+		byDelegator := method == "getDelegationsByDelegator"
+		return node.withState(func(params []json.RawMessage) (interface{}, *Error) {
+			addr, err := stringParam(params, 0)
+			if err != nil {
+				return nil, err
+			}
+			addr = normalizeAddress(addr)
+			delegations := []interface{}{}
+			for _, delegation := range node.state.delegations {
+				if (byDelegator && normalizeAddress(delegation.DelegatorAddress) == addr) || (!byDelegator && normalizeAddress(delegation.ValidatorAddress) == addr) {
+					delegations = append(delegations, renderDelegation(delegation))
+				}
+			}
+			return delegations, nil
+		})
+	}
+
+	return nil
+}
+
+// withState wraps a handler so that it's executed while holding the node mutex
+func (node *Node) withState(handler Handler) Handler {
+	return func(params []json.RawMessage) (interface{}, *Error) {
+		node.mutex.Lock()
+		defer node.mutex.Unlock()
+
+		return handler(params)
+	}
+}
+
+// receiveTransaction has to be called while holding the node mutex
+func (node *Node) receiveTransaction(params []json.RawMessage, staking bool) (interface{}, *Error) {
+	raw, err := stringParam(params, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	bytes, decodeErr := eth_hexutil.Decode(raw)
+	if decodeErr != nil {
+		return nil, &Error{Code: ErrorCodeInvalidParams, Message: decodeErr.Error()}
+	}
+
+	tx, decodeErr := decodeTransaction(bytes, staking)
+	if decodeErr != nil {
+		return nil, &Error{Code: ErrorCodeInvalidParams, Message: decodeErr.Error()}
+	}
+	tx.RawTransaction = raw
+
+	if current := node.state.nonces[tx.From]; tx.Nonce < current {
+		// Like the tx pool, a pending tx can be replaced by a tx with the same nonce and a higher gas price
+		index := node.pendingIndex(tx.From, tx.Nonce)
+		if index < 0 {
+			return nil, &Error{Code: ErrorCodeServer, Message: fmt.Sprintf("nonce too low: transaction nonce %d, current nonce %d", tx.Nonce, current)}
+		}
+		if tx.GasPrice.Cmp(node.state.pending[index].GasPrice) <= 0 {
+			return nil, &Error{Code: ErrorCodeServer, Message: "replacement transaction underpriced"}
+		}
+		node.state.pending = append(node.state.pending[:index], node.state.pending[index+1:]...)
+	} else {
+		node.state.nonces[tx.From] = tx.Nonce + 1
+	}
+
+	node.state.sent = append(node.state.sent, tx)
+	node.state.pending = append(node.state.pending, tx)
+	node.publish(rpc.NewPendingTransactionsSubscription, tx.Hash)
+
+	if node.state.autoConfirm {
+		node.confirm([]SentTransaction{tx})
+	}
+
+	return tx.Hash, nil
+}
+
+// pendingIndex has to be called while holding the node mutex, returns -1 if there's no pending tx for the sender and nonce
+func (node *Node) pendingIndex(from string, nonce uint64) int {
+	for index, tx := range node.state.pending {
+		if tx.From == from && tx.Nonce == nonce {
+			return index
+		}
+	}
+
+	return -1
+}
+
+func decodeTransaction(bytes []byte, staking bool) (SentTransaction, error) {
+	if staking {
+		tx := new(hmyStaking.StakingTransaction)
+		if err := rlp.DecodeBytes(bytes, tx); err != nil {
+			return SentTransaction{}, err
+		}
+		sender, err := hmyStaking.Sender(hmyStaking.NewEIP155Signer(tx.ChainID()), tx)
+		if err != nil {
+			return SentTransaction{}, err
+		}
+		return SentTransaction{Hash: tx.Hash().Hex(), Staking: true, From: address.ToBech32(sender), Nonce: tx.Nonce(), GasPrice: tx.GasPrice(), ShardID: tx.ShardID(), ToShardID: tx.ToShardID()}, nil
+	}
+
+	if tx := new(types.Transaction); rlp.DecodeBytes(bytes, tx) == nil {
+		sender, err := types.Sender(types.NewEIP155Signer(tx.ChainID()), tx)
+		if err != nil {
+			return SentTransaction{}, err
+		}
+		return SentTransaction{Hash: tx.Hash().Hex(), From: address.ToBech32(sender), Nonce: tx.Nonce(), GasPrice: tx.GasPrice(), ShardID: tx.ShardID(), ToShardID: tx.ToShardID(), To: toBech32(tx.To())}, nil
+	}
+
+	tx := new(types.EthTransaction)
+	if err := rlp.DecodeBytes(bytes, tx); err != nil {
+		return SentTransaction{}, err
+	}
+	sender, err := types.Sender(types.NewEIP155Signer(tx.ChainID()), tx)
+	if err != nil {
+		return SentTransaction{}, err
+	}
+
+	return SentTransaction{Hash: tx.Hash().Hex(), From: address.ToBech32(sender), Nonce: tx.Nonce(), GasPrice: tx.GasPrice(), ShardID: tx.ShardID(), ToShardID: tx.ToShardID(), To: toBech32(tx.To())}, nil
+}
+
+func (node *Node) renderBlock(block Block, fullTx bool, v2 bool) map[string]interface{} {
+	transactions := []interface{}{}
+	stakingTransactions := []interface{}{}
+
+	for _, hashes := range []struct {
+		hashes []string
+		target *[]interface{}
+	}{{block.Transactions, &transactions}, {block.StakingTransactions, &stakingTransactions}} {
+		for index, hash := range hashes.hashes {
+			if !fullTx {
+				*hashes.target = append(*hashes.target, hash)
+				continue
+			}
+			rendered := map[string]interface{}{
+				"hash":             hash,
+				"blockHash":        block.Hash,
+				"blockNumber":      quantity(block.Number, v2),
+				"transactionIndex": quantity(uint64(index), v2),
+			}
+			if receipt, ok := node.state.receipts[hash]; ok {
+				rendered["from"] = receipt.From
+				rendered["to"] = receipt.To
+				rendered["shardID"] = receipt.ShardID
+				rendered["toShardID"] = receipt.ToShardID
+			}
+			*hashes.target = append(*hashes.target, rendered)
+		}
+	}
+
+	return map[string]interface{}{
+		"number":              quantity(block.Number, v2),
+		"hash":                block.Hash,
+		"parentHash":          block.ParentHash,
+		"timestamp":           quantity(uint64(block.Timestamp.Unix()), v2),
+		"epoch":               quantity(uint64(block.Epoch), v2),
+		"viewID":              quantity(block.Number, v2),
+		"shardID":             node.ShardID,
+		"transactions":        transactions,
+		"stakingTransactions": stakingTransactions,
+	}
+}
+
+func renderReceipt(receipt Receipt, v2 bool) map[string]interface{} {
+	rendered := map[string]interface{}{
+		"transactionHash":   receipt.TransactionHash,
+		"blockHash":         receipt.BlockHash,
+		"blockNumber":       quantity(receipt.BlockNumber, v2),
+		"transactionIndex":  quantity(receipt.TransactionIndex, v2),
+		"status":            quantity(receipt.Status, v2),
+		"gasUsed":           quantity(receipt.GasUsed, v2),
+		"cumulativeGasUsed": quantity(receipt.GasUsed, v2),
+		"contractAddress":   nil,
+		"logs":              []interface{}{},
+		"shardID":           receipt.ShardID,
+		"toShardID":         receipt.ToShardID,
+	}
+
+	if receipt.Staking {
+		rendered["sender"] = receipt.From
+	} else {
+		rendered["from"] = receipt.From
+		rendered["to"] = receipt.To
+	}
+
+	return rendered
+}
+
+func renderCXReceipt(receipt CXReceipt, v2 bool) map[string]interface{} {
+	return map[string]interface{}{
+		"blockHash":   receipt.BlockHash,
+		"blockNumber": quantity(receipt.BlockNumber, v2),
+		"hash":        receipt.TransactionHash,
+		"from":        receipt.From,
+		"to":          receipt.To,
+		"shardID":     receipt.ShardID,
+		"toShardID":   receipt.ToShardID,
+		"value":       bigQuantity(receipt.Value, v2),
+	}
+}
+
+func renderDelegation(delegation Delegation) map[string]interface{} {
+	undelegations := []map[string]interface{}{}
+	for _, undelegation := range delegation.Undelegations {
+		undelegations = append(undelegations, map[string]interface{}{"Amount": bigOrZero(undelegation.Amount), "Epoch": undelegation.Epoch})
+	}
+
+	return map[string]interface{}{
+		"delegator_address": delegation.DelegatorAddress,
+		"validator_address": delegation.ValidatorAddress,
+		"amount":            bigOrZero(delegation.Amount),
+		"reward":            bigOrZero(delegation.Reward),
+		"Undelegations":     undelegations,
+	}
+}
+
+// blockNumberParam has to be called while holding the node mutex
+func (node *Node) blockNumberParam(params []json.RawMessage, index int) (uint64, *Error) {
+	if index >= len(params) {
+		return 0, &Error{Code: ErrorCodeInvalidParams, Message: fmt.Sprintf("missing value for required argument %d", index)}
+	}
+
+	var number uint64
+	if err := json.Unmarshal(params[index], &number); err == nil {
+		return number, nil
+	}
+
+	value, err := stringParam(params, index)
+	if err != nil {
+		return 0, err
+	}
+
+	switch value {
+	case "latest", "pending":
+		return node.state.blockNumber, nil
+	case "earliest":
+		return 0, nil
+	}
+
+	number, parseErr := strconv.ParseUint(strings.TrimPrefix(value, "0x"), 16, 64)
+	if parseErr != nil {
+		return 0, &Error{Code: ErrorCodeInvalidParams, Message: parseErr.Error()}
+	}
+
+	return number, nil
+}
+
+func stringParam(params []json.RawMessage, index int) (string, *Error) {
+	var value string
+	if err := decodeParam(params, index, &value); err != nil {
+		return "", err
+	}
+
+	return value, nil
+}
+
+func boolParam(params []json.RawMessage, index int) (bool, *Error) {
+	var value bool
+	if err := decodeParam(params, index, &value); err != nil {
+		return false, err
+	}
+
+	return value, nil
+}
+
+// blockArgsParam accepts both the v1 fullTx bool and the v2 BlockArgs object
+func blockArgsParam(params []json.RawMessage, index int) (fullTx bool, withSigners bool) {
+	if value, err := boolParam(params, index); err == nil {
+		return value, false
+	}
+
+	args := struct {
+		FullTx      bool `json:"fullTx"`
+		WithSigners bool `json:"withSigners"`
+	}{}
+	if err := decodeParam(params, index, &args); err != nil {
+		return false, false
+	}
+
+	return args.FullTx, args.WithSigners
+}
+
+func intParam(params []json.RawMessage, index int) (int, *Error) {
+	var value int
+	if err := decodeParam(params, index, &value); err != nil {
+		return 0, err
+	}
+
+	return value, nil
+}
+
+func decodeParam(params []json.RawMessage, index int, value interface{}) *Error {
+	if index >= len(params) {
+		return &Error{Code: ErrorCodeInvalidParams, Message: fmt.Sprintf("missing value for required argument %d", index)}
+	}
+
+	if err := json.Unmarshal(params[index], value); err != nil {
+		return &Error{Code: ErrorCodeInvalidParams, Message: fmt.Sprintf("invalid argument %d: %s", index, err.Error())}
+	}
+
+	return nil
+}
+
+func quantity(value uint64, v2 bool) interface{} {
+	if v2 {
+		return value
+	}
+
+	return eth_hexutil.EncodeUint64(value)
+}
+
+func bigQuantity(value *big.Int, v2 bool) interface{} {
+	value = bigOrZero(value)
+	if v2 {
+		return value
+	}
+
+	return eth_hexutil.EncodeBig(value)
+}
+
+func bigOrZero(value *big.Int) *big.Int {
+	if value == nil {
+		return big.NewInt(0)
+	}
+
+	return value
+}
+
+func toBech32(to *address.T) string {
+	if to == nil {
+		return ""
+	}
+
+	return address.ToBech32(*to)
+}
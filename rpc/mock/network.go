@@ -0,0 +1,54 @@
+package mock
+
+import (
+	"github.com/harmony-one/go-sdk/pkg/sharding"
+)
+
+// Network - a set of mock nodes, one per shard, all serving the same sharding structure
+type Network struct {
+	Nodes []*Node
+}
+
+// NewNetwork - starts a mock node for every shard of a network with a given shard count
+func NewNetwork(shardCount int) *Network {
+	network := &Network{}
+	routes := []sharding.RPCRoutes{}
+
+	for shardID := 0; shardID < shardCount; shardID++ {
+		node := NewShardNode(uint32(shardID))
+		network.Nodes = append(network.Nodes, node)
+		routes = append(routes, sharding.RPCRoutes{HTTP: node.URL, ShardID: shardID})
+	}
+
+	for _, node := range network.Nodes {
+		node.SetShardingStructure(routes)
+	}
+
+	return network
+}
+
+// Node - returns the mock node for a given shard
+func (network *Network) Node(shardID uint32) *Node {
+	if int(shardID) >= len(network.Nodes) {
+		return nil
+	}
+
+	return network.Nodes[shardID]
+}
+
+// ShardsToMap - returns the node url for every shard, matches the format used by the balance helpers
+func (network *Network) ShardsToMap() map[uint32]string {
+	shards := make(map[uint32]string)
+	for _, node := range network.Nodes {
+		shards[node.ShardID] = node.URL
+	}
+
+	return shards
+}
+
+// Close - shuts down all mock nodes
+func (network *Network) Close() {
+	for _, node := range network.Nodes {
+		node.Close()
+	}
+}
@@ -0,0 +1,289 @@
+package mock
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// Standard JSON-RPC error codes returned by the mock node
+const (
+	ErrorCodeParse          = -32700
+	ErrorCodeInvalidRequest = -32600
+	ErrorCodeMethodNotFound = -32601
+	ErrorCodeInvalidParams  = -32602
+	ErrorCodeServer         = -32000
+)
+
+// Error - a JSON-RPC error returned by the mock node
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Handler - custom handler for a JSON-RPC method, receives the raw params and returns the result or an error
+type Handler func(params []json.RawMessage) (interface{}, *Error)
+
+// Node - in-process fake Harmony node serving the hmy_ / hmyv2_ JSON-RPC methods used by go-lib
+// hmy_ methods are answered using the v1 (hex encoded) formats, hmyv2_ methods using the v2 (numeric) formats
+type Node struct {
+	Server  *httptest.Server
+	URL     string
+	WSURL   string // WSURL - WebSocket endpoint serving the hmy_subscribe subscriptions
+	ShardID uint32
+
+	mutex    sync.Mutex
+	state    *state
+	handlers map[string]Handler
+	faults   []*fault
+	latency  map[string]time.Duration
+	calls    map[string]int
+
+	wsConns    map[*wsConn]bool
+	wsDisabled bool
+}
+
+type fault struct {
+	method     string
+	remaining  int
+	err        *Error
+	statusCode int
+}
+
+type request struct {
+	ID      json.RawMessage   `json:"id"`
+	JSONRPC string            `json:"jsonrpc"`
+	Method  string            `json:"method"`
+	Params  []json.RawMessage `json:"params"`
+}
+
+type response struct {
+	ID      json.RawMessage `json:"id"`
+	JSONRPC string          `json:"jsonrpc"`
+	Result  interface{}     `json:"result"`
+	Error   *Error          `json:"error,omitempty"`
+}
+
+// NewNode - starts a new mock node for shard 0
+func NewNode() *Node {
+	return NewShardNode(0)
+}
+
+// NewShardNode - starts a new mock node for a given shard
+func NewShardNode(shardID uint32) *Node {
+	node := &Node{
+		ShardID:  shardID,
+		state:    newState(),
+		handlers: make(map[string]Handler),
+		latency:  make(map[string]time.Duration),
+		calls:    make(map[string]int),
+		wsConns:  make(map[*wsConn]bool),
+	}
+
+	node.Server = httptest.NewServer(http.HandlerFunc(node.serveHTTP))
+	node.URL = node.Server.URL
+	node.WSURL = "ws" + strings.TrimPrefix(node.URL, "http")
+
+	return node
+}
+
+// Close - shuts down the mock node
+func (node *Node) Close() {
+	node.DropWebSocketConnections()
+	node.Server.Close()
+}
+
+// Handle - overrides the handler for a given method (without the hmy_ / hmyv2_ prefix, e.g. "getBalance")
+func (node *Node) Handle(method string, handler Handler) {
+	node.mutex.Lock()
+	defer node.mutex.Unlock()
+
+	node.handlers[normalizeMethod(method)] = handler
+}
+
+// FailNext - makes the next count calls of a given method (or any method if method is empty) return a JSON-RPC error, a count <= 0 is ignored
+func (node *Node) FailNext(method string, count int, code int, message string) {
+	node.addFault(&fault{method: normalizeMethod(method), remaining: count, err: &Error{Code: code, Message: message}})
+}
+
+// FailNextHTTP - makes the next count calls of a given method (or any method if method is empty) return a given HTTP status code, a count <= 0 is ignored
+func (node *Node) FailNextHTTP(method string, count int, statusCode int) {
+	node.addFault(&fault{method: normalizeMethod(method), remaining: count, statusCode: statusCode})
+}
+
+// SetLatency - delays every response for a given method (or any method if method is empty)
+func (node *Node) SetLatency(method string, latency time.Duration) {
+	node.mutex.Lock()
+	defer node.mutex.Unlock()
+
+	node.latency[normalizeMethod(method)] = latency
+}
+
+// Calls - returns the number of calls received for a given method (or all methods if method is empty)
+func (node *Node) Calls(method string) int {
+	node.mutex.Lock()
+	defer node.mutex.Unlock()
+
+	if method == "" {
+		total := 0
+		for _, count := range node.calls {
+			total += count
+		}
+		return total
+	}
+
+	return node.calls[normalizeMethod(method)]
+}
+
+// Reset - clears all state, handlers, faults, latencies and call counters
+func (node *Node) Reset() {
+	node.mutex.Lock()
+	defer node.mutex.Unlock()
+
+	node.state = newState()
+	node.handlers = make(map[string]Handler)
+	node.faults = nil
+	node.latency = make(map[string]time.Duration)
+	node.calls = make(map[string]int)
+}
+
+func (node *Node) addFault(f *fault) {
+	if f.remaining <= 0 {
+		return
+	}
+
+	node.mutex.Lock()
+	defer node.mutex.Unlock()
+
+	node.faults = append(node.faults, f)
+}
+
+func (node *Node) serveHTTP(writer http.ResponseWriter, httpRequest *http.Request) {
+	if websocket.IsWebSocketUpgrade(httpRequest) {
+		node.serveWebSocket(writer, httpRequest)
+		return
+	}
+
+	body, err := ioutil.ReadAll(httpRequest.Body)
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	trimmed := strings.TrimSpace(string(body))
+	if strings.HasPrefix(trimmed, "[") {
+		requests := []request{}
+		if err := json.Unmarshal(body, &requests); err != nil {
+			writeJSON(writer, http.StatusOK, response{JSONRPC: "2.0", Error: &Error{Code: ErrorCodeParse, Message: err.Error()}})
+			return
+		}
+
+		responses := make([]response, 0, len(requests))
+		for _, req := range requests {
+			resp, statusCode := node.process(req)
+			if statusCode != http.StatusOK {
+				writer.WriteHeader(statusCode)
+				return
+			}
+			responses = append(responses, resp)
+		}
+
+		writeJSON(writer, http.StatusOK, responses)
+		return
+	}
+
+	req := request{}
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeJSON(writer, http.StatusOK, response{JSONRPC: "2.0", Error: &Error{Code: ErrorCodeParse, Message: err.Error()}})
+		return
+	}
+
+	resp, statusCode := node.process(req)
+	if statusCode != http.StatusOK {
+		writer.WriteHeader(statusCode)
+		return
+	}
+
+	writeJSON(writer, http.StatusOK, resp)
+}
+
+func (node *Node) process(req request) (response, int) {
+	method := normalizeMethod(req.Method)
+	v2 := strings.HasPrefix(req.Method, "hmyv2_")
+	resp := response{ID: req.ID, JSONRPC: "2.0"}
+
+	node.mutex.Lock()
+	node.calls[method]++
+	latency, ok := node.latency[method]
+	if !ok {
+		latency = node.latency[""]
+	}
+	f := node.takeFault(method)
+	handler := node.handlers[method]
+	node.mutex.Unlock()
+
+	if latency > 0 {
+		time.Sleep(latency)
+	}
+
+	if f != nil {
+		if f.statusCode != 0 {
+			return resp, f.statusCode
+		}
+		resp.Error = f.err
+		return resp, http.StatusOK
+	}
+
+	if handler == nil {
+		handler = node.builtinHandler(method, v2)
+	}
+
+	if handler == nil {
+		resp.Error = &Error{Code: ErrorCodeMethodNotFound, Message: "the method " + req.Method + " does not exist/is not available"}
+		return resp, http.StatusOK
+	}
+
+	resp.Result, resp.Error = handler(req.Params)
+
+	return resp, http.StatusOK
+}
+
+// takeFault has to be called while holding the node mutex
+func (node *Node) takeFault(method string) *fault {
+	for i, f := range node.faults {
+		if f.method != "" && f.method != method {
+			continue
+		}
+
+		f.remaining--
+		if f.remaining <= 0 {
+			node.faults = append(node.faults[:i], node.faults[i+1:]...)
+		}
+
+		return f
+	}
+
+	return nil
+}
+
+func normalizeMethod(method string) string {
+	for _, prefix := range []string{"hmyv2_", "hmy_"} {
+		if strings.HasPrefix(method, prefix) {
+			return strings.TrimPrefix(method, prefix)
+		}
+	}
+
+	return method
+}
+
+func writeJSON(writer http.ResponseWriter, statusCode int, value interface{}) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(statusCode)
+	json.NewEncoder(writer).Encode(value)
+}
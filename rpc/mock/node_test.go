@@ -0,0 +1,171 @@
+package mock_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net/http"
+	"testing"
+
+	ethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/harmony-one/go-lib/network/rpc/balances"
+	commonTypes "github.com/harmony-one/go-lib/network/types/common"
+	"github.com/harmony-one/go-lib/rpc"
+	"github.com/harmony-one/go-lib/rpc/mock"
+	"github.com/harmony-one/go-lib/transactions"
+	"github.com/harmony-one/go-sdk/pkg/address"
+	goSdkRPC "github.com/harmony-one/go-sdk/pkg/rpc"
+	"github.com/harmony-one/harmony/numeric"
+)
+
+var (
+	testAddress      = address.ToBech32(ethCommon.HexToAddress("0x1111111111111111111111111111111111111111"))
+	otherTestAddress = address.ToBech32(ethCommon.HexToAddress("0x2222222222222222222222222222222222222222"))
+)
+
+func newTestClient(node *mock.Node, attempts int) *rpc.Client {
+	return rpc.NewClientWithRetry(node.URL, &commonTypes.Retry{Attempts: attempts})
+}
+
+func TestBalances(t *testing.T) {
+	node := mock.NewNode()
+	defer node.Close()
+
+	balance, _ := new(big.Int).SetString("1500000000000000000", 10)
+	node.SetBalance(testAddress, balance)
+
+	results, err := balances.GetBalancesWithContext(context.Background(), newTestClient(node, 1), []string{testAddress, otherTestAddress})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+
+	if results[0].Error != nil || !results[0].Balance.Equal(numeric.MustNewDecFromStr("1.5")) {
+		t.Errorf("expected a balance of 1.5, got %s (error: %v)", results[0].Balance, results[0].Error)
+	}
+
+	if results[1].Error != nil || !results[1].Balance.IsZero() {
+		t.Errorf("expected a balance of 0 for an unknown address, got %s (error: %v)", results[1].Balance, results[1].Error)
+	}
+}
+
+func TestReceipts(t *testing.T) {
+	node := mock.NewNode()
+	defer node.Close()
+
+	txHash := "0x" + ethCommon.Bytes2Hex(ethCommon.LeftPadBytes([]byte{1}, 32))
+	node.SetReceipt(mock.Receipt{TransactionHash: txHash, BlockNumber: 5, Status: 1, GasUsed: 21000, From: testAddress, To: otherTestAddress})
+
+	client := newTestClient(node, 1)
+
+	receipt, err := transactions.GetTransactionReceiptWithContext(context.Background(), client, txHash)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if receipt == nil {
+		t.Fatal("expected a receipt")
+	}
+
+	if receipt.BlockNumber != 5 || receipt.GasUsed != 21000 || !receipt.IsSuccessful() || receipt.From != testAddress {
+		t.Errorf("unexpected receipt: %+v", receipt)
+	}
+
+	missing, err := transactions.GetTransactionReceiptWithContext(context.Background(), client, "0x"+ethCommon.Bytes2Hex(ethCommon.LeftPadBytes([]byte{2}, 32)))
+	if err != nil || missing != nil {
+		t.Errorf("expected no receipt for an unknown tx, got %+v (error: %v)", missing, err)
+	}
+}
+
+func TestErrorSinks(t *testing.T) {
+	node := mock.NewNode()
+	defer node.Close()
+
+	node.AddTransactionFailure(rpc.Failure{TxHashID: "0x01", ErrorMessage: "nonce too low"})
+	node.AddStakingFailure(rpc.Failure{TxHashID: "0x02", DirectiveKind: "Delegate", ErrorMessage: "insufficient balance"})
+
+	client := newTestClient(node, 1)
+
+	txFailures, err := rpc.TransactionFailuresWithContext(context.Background(), client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if failure, ok := rpc.FailureOccurredForTransaction(txFailures, "0x01"); !ok || failure.ErrorMessage != "nonce too low" {
+		t.Errorf("expected a transaction failure for 0x01, got %+v", txFailures)
+	}
+
+	if _, ok := rpc.FailureOccurredForTransaction(txFailures, "0x02"); ok {
+		t.Error("staking failures shouldn't be reported by the transaction error sink")
+	}
+
+	stakingFailures, err := rpc.StakingFailuresWithContext(context.Background(), client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if failure, ok := rpc.FailureOccurredForTransaction(stakingFailures, "0x02"); !ok || failure.DirectiveKind != "Delegate" {
+		t.Errorf("expected a staking failure for 0x02, got %+v", stakingFailures)
+	}
+}
+
+func TestFailNext(t *testing.T) {
+	node := mock.NewNode()
+	defer node.Close()
+
+	node.FailNext("blockNumber", 1, mock.ErrorCodeServer, "boom")
+
+	client := newTestClient(node, 3)
+
+	_, err := client.Request(context.Background(), goSdkRPC.Method.BlockNumber, nil)
+	var rpcError rpc.RPCError
+	if !errors.As(err, &rpcError) || rpcError.Code != mock.ErrorCodeServer || rpcError.Message != "boom" {
+		t.Fatalf("expected the injected rpc error, got %v", err)
+	}
+
+	if calls := node.Calls("blockNumber"); calls != 1 {
+		t.Errorf("non retryable rpc errors shouldn't be retried, got %d calls", calls)
+	}
+
+	if _, err := client.Request(context.Background(), goSdkRPC.Method.BlockNumber, nil); err != nil {
+		t.Errorf("expected the fault to be used up, got %v", err)
+	}
+}
+
+func TestFailNextHTTP(t *testing.T) {
+	node := mock.NewNode()
+	defer node.Close()
+
+	node.FailNextHTTP("", 2, http.StatusServiceUnavailable)
+
+	if _, err := newTestClient(node, 3).Request(context.Background(), goSdkRPC.Method.BlockNumber, nil); err != nil {
+		t.Fatalf("expected the request to succeed after retrying, got %v", err)
+	}
+
+	if calls := node.Calls("blockNumber"); calls != 3 {
+		t.Errorf("expected 3 calls, got %d", calls)
+	}
+
+	node.FailNextHTTP("blockNumber", 1, http.StatusServiceUnavailable)
+
+	_, err := newTestClient(node, 1).Request(context.Background(), goSdkRPC.Method.BlockNumber, nil)
+	var statusError *rpc.HTTPStatusError
+	if !errors.As(err, &statusError) || statusError.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected the injected status code, got %v", err)
+	}
+}
+
+func TestFailNextIgnoresNonPositiveCounts(t *testing.T) {
+	node := mock.NewNode()
+	defer node.Close()
+
+	node.FailNext("", 0, mock.ErrorCodeServer, "boom")
+	node.FailNextHTTP("", -1, http.StatusInternalServerError)
+
+	if _, err := newTestClient(node, 1).Request(context.Background(), goSdkRPC.Method.BlockNumber, nil); err != nil {
+		t.Errorf("faults with a count <= 0 shouldn't fail any call, got %v", err)
+	}
+}
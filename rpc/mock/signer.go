@@ -0,0 +1,137 @@
+package mock
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	eth_hexutil "github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/harmony-one/go-lib/signers"
+	"github.com/harmony-one/harmony/core/types"
+	hmyStaking "github.com/harmony-one/harmony/staking/types"
+)
+
+// RemoteSigner - in-process stand-in for a remote signing service speaking the signers.RemoteSigner protocol
+// Received txs are signed using a local signer, requests for any other account than the local signer's are rejected
+type RemoteSigner struct {
+	Server *httptest.Server
+	URL    string
+	Signer signers.Signer
+
+	mutex    sync.Mutex
+	requests []signers.RemoteSignRequest
+	err      string
+}
+
+// NewRemoteSigner - starts a new remote signer stand-in signing txs using a given signer
+func NewRemoteSigner(signer signers.Signer) *RemoteSigner {
+	remote := &RemoteSigner{Signer: signer}
+
+	remote.Server = httptest.NewServer(http.HandlerFunc(remote.serveHTTP))
+	remote.URL = remote.Server.URL
+
+	return remote
+}
+
+// Close - shuts down the remote signer
+func (remote *RemoteSigner) Close() {
+	remote.Server.Close()
+}
+
+// FailWith - makes every following request fail with a given error message, an empty message clears the error
+func (remote *RemoteSigner) FailWith(message string) {
+	remote.mutex.Lock()
+	defer remote.mutex.Unlock()
+
+	remote.err = message
+}
+
+// Requests - returns the sign requests received so far
+func (remote *RemoteSigner) Requests() []signers.RemoteSignRequest {
+	remote.mutex.Lock()
+	defer remote.mutex.Unlock()
+
+	return append([]signers.RemoteSignRequest{}, remote.requests...)
+}
+
+func (remote *RemoteSigner) serveHTTP(writer http.ResponseWriter, httpRequest *http.Request) {
+	if httpRequest.Method != http.MethodPost {
+		writeJSON(writer, http.StatusMethodNotAllowed, signers.RemoteSignResponse{Error: "only POST requests are supported"})
+		return
+	}
+
+	var request signers.RemoteSignRequest
+	if err := json.NewDecoder(httpRequest.Body).Decode(&request); err != nil {
+		writeJSON(writer, http.StatusBadRequest, signers.RemoteSignResponse{Error: err.Error()})
+		return
+	}
+
+	remote.mutex.Lock()
+	remote.requests = append(remote.requests, request)
+	failure := remote.err
+	remote.mutex.Unlock()
+
+	if failure != "" {
+		writeJSON(writer, http.StatusInternalServerError, signers.RemoteSignResponse{Error: failure})
+		return
+	}
+
+	if request.Address != remote.Signer.Address() {
+		writeJSON(writer, http.StatusForbidden, signers.RemoteSignResponse{Error: fmt.Sprintf("unknown account %s", request.Address)})
+		return
+	}
+
+	signed, err := remote.sign(request)
+	if err != nil {
+		writeJSON(writer, http.StatusBadRequest, signers.RemoteSignResponse{Error: err.Error()})
+		return
+	}
+
+	writeJSON(writer, http.StatusOK, signers.RemoteSignResponse{RawTransaction: eth_hexutil.Encode(signed)})
+}
+
+func (remote *RemoteSigner) sign(request signers.RemoteSignRequest) ([]byte, error) {
+	chainID, ok := new(big.Int).SetString(request.ChainID, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid chain id %q", request.ChainID)
+	}
+
+	unsigned, err := eth_hexutil.Decode(request.RawTransaction)
+	if err != nil {
+		return nil, err
+	}
+
+	var signed interface{}
+	switch request.Type {
+	case signers.TypeTransaction:
+		tx := new(types.Transaction)
+		if err := rlp.DecodeBytes(unsigned, tx); err != nil {
+			return nil, err
+		}
+		signed, err = remote.Signer.SignTx(tx, chainID)
+	case signers.TypeEthTransaction:
+		tx := new(types.EthTransaction)
+		if err := rlp.DecodeBytes(unsigned, tx); err != nil {
+			return nil, err
+		}
+		signed, err = remote.Signer.SignEthTx(tx, chainID)
+	case signers.TypeStaking:
+		tx := new(hmyStaking.StakingTransaction)
+		if err := rlp.DecodeBytes(unsigned, tx); err != nil {
+			return nil, err
+		}
+		signed, err = remote.Signer.SignStakingTx(tx, chainID)
+	default:
+		return nil, fmt.Errorf("unsupported transaction type %q", request.Type)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return rlp.EncodeToBytes(signed)
+}
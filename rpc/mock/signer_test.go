@@ -0,0 +1,75 @@
+package mock_test
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	ethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/harmony-one/go-lib/rpc/mock"
+	"github.com/harmony-one/go-lib/signers"
+	"github.com/harmony-one/go-sdk/pkg/address"
+	"github.com/harmony-one/harmony/core/types"
+)
+
+func newTestSigner(t *testing.T) *signers.PrivateKeySigner {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("failed to generate a key: %v", err)
+	}
+
+	return signers.NewECDSAKeySigner(key)
+}
+
+func TestRemoteSignerRoundTrip(t *testing.T) {
+	local := newTestSigner(t)
+	server := mock.NewRemoteSigner(local)
+	defer server.Close()
+
+	chainID := big.NewInt(2)
+	amount, _ := new(big.Int).SetString("1000000000000000000", 10)
+	tx := types.NewTransaction(7, ethCommon.HexToAddress("0x2222222222222222222222222222222222222222"), 0, amount, 21000, big.NewInt(1000000000), nil)
+
+	signedTx, err := signers.NewRemoteSigner(server.URL, local.Address()).SignTx(tx, chainID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sender, err := types.Sender(types.NewEIP155Signer(chainID), signedTx)
+	if err != nil {
+		t.Fatalf("failed to recover the sender: %v", err)
+	}
+
+	if address.ToBech32(sender) != local.Address() {
+		t.Errorf("expected the tx to be signed by %s, got %s", local.Address(), address.ToBech32(sender))
+	}
+
+	if signedTx.Nonce() != tx.Nonce() || signedTx.Value().Cmp(amount) != 0 {
+		t.Errorf("expected the signed tx to keep the payload, got nonce %d and amount %s", signedTx.Nonce(), signedTx.Value())
+	}
+
+	requests := server.Requests()
+	if len(requests) != 1 || requests[0].Type != signers.TypeTransaction || requests[0].ChainID != "2" || requests[0].Address != local.Address() {
+		t.Errorf("unexpected sign requests: %+v", requests)
+	}
+}
+
+func TestRemoteSignerErrors(t *testing.T) {
+	local := newTestSigner(t)
+	server := mock.NewRemoteSigner(local)
+	defer server.Close()
+
+	chainID := big.NewInt(2)
+	tx := types.NewTransaction(0, ethCommon.HexToAddress("0x2222222222222222222222222222222222222222"), 0, big.NewInt(1), 21000, big.NewInt(1000000000), nil)
+
+	if _, err := signers.NewRemoteSigner(server.URL, newTestSigner(t).Address()).SignTx(tx, chainID); err == nil || !strings.Contains(err.Error(), "unknown account") {
+		t.Errorf("expected an unknown account error, got %v", err)
+	}
+
+	server.FailWith("signing disabled")
+
+	if _, err := signers.NewRemoteSigner(server.URL, local.Address()).SignTx(tx, chainID); err == nil || !strings.Contains(err.Error(), "signing disabled") {
+		t.Errorf("expected the injected error, got %v", err)
+	}
+}
@@ -0,0 +1,353 @@
+package mock
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/harmony-one/go-lib/rpc"
+	"github.com/harmony-one/go-sdk/pkg/address"
+	"github.com/harmony-one/go-sdk/pkg/sharding"
+)
+
+// Block - a block served by the mock node
+type Block struct {
+	Number              uint64
+	Hash                string
+	ParentHash          string
+	Timestamp           time.Time
+	Epoch               uint32
+	Transactions        []string // Transactions - hashes of the regular txs included in the block
+	StakingTransactions []string // StakingTransactions - hashes of the staking txs included in the block
+	Signers             []string // Signers - bech32 addresses returned when the block is requested with signers
+}
+
+// Receipt - a transaction receipt served by the mock node
+type Receipt struct {
+	TransactionHash  string
+	BlockHash        string
+	BlockNumber      uint64
+	TransactionIndex uint64
+	Status           uint64
+	GasUsed          uint64
+	From             string
+	To               string
+	ShardID          uint32
+	ToShardID        uint32
+	Staking          bool
+}
+
+// CXReceipt - a cross shard receipt served by the destination shard's mock node
+type CXReceipt struct {
+	TransactionHash string
+	BlockHash       string
+	BlockNumber     uint64
+	From            string
+	To              string
+	ShardID         uint32
+	ToShardID       uint32
+	Value           *big.Int
+}
+
+// Delegation - a delegation served by the mock node
+type Delegation struct {
+	DelegatorAddress string
+	ValidatorAddress string
+	Amount           *big.Int
+	Reward           *big.Int
+	Undelegations    []Undelegation
+}
+
+// Undelegation - a pending undelegation served by the mock node
+type Undelegation struct {
+	Amount *big.Int
+	Epoch  int
+}
+
+// SentTransaction - a raw transaction received via sendRawTransaction / sendRawStakingTransaction
+type SentTransaction struct {
+	Hash           string
+	RawTransaction string
+	Staking        bool
+	From           string
+	Nonce          uint64
+	GasPrice       *big.Int
+	ShardID        uint32
+	ToShardID      uint32
+	To             string
+}
+
+type state struct {
+	blockNumber       uint64
+	blocks            map[uint64]Block
+	epoch             uint32
+	balances          map[string]*big.Int
+	nonces            map[string]uint64
+	receipts          map[string]Receipt
+	cxReceipts        map[string]CXReceipt
+	txFailures        []rpc.Failure
+	stakingFailures   []rpc.Failure
+	shardingStructure []sharding.RPCRoutes
+	validators        map[string]interface{}
+	elected           []string
+	delegations       []Delegation
+	sent              []SentTransaction
+	pending           []SentTransaction
+	autoConfirm       bool
+	redelegationEpoch *big.Int
+}
+
+func newState() *state {
+	return &state{
+		blocks:     make(map[uint64]Block),
+		balances:   make(map[string]*big.Int),
+		nonces:     make(map[string]uint64),
+		receipts:   make(map[string]Receipt),
+		cxReceipts: make(map[string]CXReceipt),
+		validators: make(map[string]interface{}),
+
+		redelegationEpoch: big.NewInt(0),
+	}
+}
+
+// SetBlockNumber - sets the current block number
+func (node *Node) SetBlockNumber(blockNumber uint64) {
+	node.mutex.Lock()
+	defer node.mutex.Unlock()
+
+	node.state.blockNumber = blockNumber
+}
+
+// AddBlock - adds a block, the current block number is moved to the block number if it's higher
+func (node *Node) AddBlock(block Block) {
+	node.mutex.Lock()
+	defer node.mutex.Unlock()
+
+	node.addBlock(block)
+}
+
+// SetEpoch - sets the current epoch
+func (node *Node) SetEpoch(epoch uint32) {
+	node.mutex.Lock()
+	defer node.mutex.Unlock()
+
+	node.state.epoch = epoch
+}
+
+// SetRedelegationEpoch - sets the epoch from which undelegated tokens can be redelegated (as reported by getNodeMetadata), nil disables redelegation
+func (node *Node) SetRedelegationEpoch(epoch *big.Int) {
+	node.mutex.Lock()
+	defer node.mutex.Unlock()
+
+	node.state.redelegationEpoch = epoch
+}
+
+// SetBalance - sets the balance (in atto) for a given bech32 or hex address
+func (node *Node) SetBalance(addr string, balance *big.Int) {
+	node.mutex.Lock()
+	defer node.mutex.Unlock()
+
+	node.state.balances[normalizeAddress(addr)] = new(big.Int).Set(balance)
+}
+
+// SetNonce - sets the nonce for a given bech32 or hex address
+func (node *Node) SetNonce(addr string, nonce uint64) {
+	node.mutex.Lock()
+	defer node.mutex.Unlock()
+
+	node.state.nonces[normalizeAddress(addr)] = nonce
+}
+
+// SetReceipt - sets the receipt for a given transaction
+func (node *Node) SetReceipt(receipt Receipt) {
+	node.mutex.Lock()
+	defer node.mutex.Unlock()
+
+	node.state.receipts[receipt.TransactionHash] = receipt
+}
+
+// SetCXReceipt - sets the cross shard receipt for a given transaction, marking the transfer as credited on this (destination) shard
+func (node *Node) SetCXReceipt(receipt CXReceipt) {
+	node.mutex.Lock()
+	defer node.mutex.Unlock()
+
+	node.state.cxReceipts[receipt.TransactionHash] = receipt
+}
+
+// AddTransactionFailure - adds a failure to the transaction error sink
+func (node *Node) AddTransactionFailure(failure rpc.Failure) {
+	node.mutex.Lock()
+	defer node.mutex.Unlock()
+
+	node.state.txFailures = append(node.state.txFailures, failure)
+}
+
+// AddStakingFailure - adds a failure to the staking error sink
+func (node *Node) AddStakingFailure(failure rpc.Failure) {
+	node.mutex.Lock()
+	defer node.mutex.Unlock()
+
+	node.state.stakingFailures = append(node.state.stakingFailures, failure)
+}
+
+// SetShardingStructure - sets the sharding structure, defaults to a single shard served by this node
+func (node *Node) SetShardingStructure(routes []sharding.RPCRoutes) {
+	node.mutex.Lock()
+	defer node.mutex.Unlock()
+
+	node.state.shardingStructure = routes
+}
+
+// SetValidator - sets the validator information (as returned by getValidatorInformation) for a given validator address
+func (node *Node) SetValidator(addr string, information interface{}) {
+	node.mutex.Lock()
+	defer node.mutex.Unlock()
+
+	node.state.validators[normalizeAddress(addr)] = information
+}
+
+// SetElectedValidators - sets the addresses of the elected validators
+func (node *Node) SetElectedValidators(addresses []string) {
+	node.mutex.Lock()
+	defer node.mutex.Unlock()
+
+	node.state.elected = addresses
+}
+
+// AddDelegation - adds a delegation
+func (node *Node) AddDelegation(delegation Delegation) {
+	node.mutex.Lock()
+	defer node.mutex.Unlock()
+
+	node.state.delegations = append(node.state.delegations, delegation)
+}
+
+// SetAutoConfirm - if enabled every sent transaction is immediately included in a new block with a successful receipt
+func (node *Node) SetAutoConfirm(autoConfirm bool) {
+	node.mutex.Lock()
+	defer node.mutex.Unlock()
+
+	node.state.autoConfirm = autoConfirm
+}
+
+// SentTransactions - returns all transactions received by the node
+func (node *Node) SentTransactions() []SentTransaction {
+	node.mutex.Lock()
+	defer node.mutex.Unlock()
+
+	return append([]SentTransaction{}, node.state.sent...)
+}
+
+// ConfirmPending - includes all pending transactions in a new block with successful receipts and returns the block
+func (node *Node) ConfirmPending() Block {
+	node.mutex.Lock()
+	defer node.mutex.Unlock()
+
+	return node.confirm(node.state.pending)
+}
+
+// FailPending - removes all pending transactions and reports them in the error sinks with a given error message
+func (node *Node) FailPending(message string) {
+	node.mutex.Lock()
+	defer node.mutex.Unlock()
+
+	for _, tx := range node.state.pending {
+		failure := rpc.Failure{ErrorMessage: message, TimeAtRejection: uint32(time.Now().Unix()), TxHashID: tx.Hash}
+		if tx.Staking {
+			node.state.stakingFailures = append(node.state.stakingFailures, failure)
+		} else {
+			node.state.txFailures = append(node.state.txFailures, failure)
+		}
+	}
+
+	node.state.pending = nil
+}
+
+// addBlock has to be called while holding the node mutex
+func (node *Node) addBlock(block Block) {
+	if block.Hash == "" {
+		block.Hash = hashOf(fmt.Sprintf("block-%d-%d", node.ShardID, block.Number))
+	}
+	if block.ParentHash == "" && block.Number > 0 {
+		block.ParentHash = hashOf(fmt.Sprintf("block-%d-%d", node.ShardID, block.Number-1))
+	}
+	if block.Timestamp.IsZero() {
+		block.Timestamp = time.Now().UTC()
+	}
+	if block.Epoch == 0 {
+		block.Epoch = node.state.epoch
+	}
+
+	node.state.blocks[block.Number] = block
+	if block.Number > node.state.blockNumber {
+		node.state.blockNumber = block.Number
+	}
+
+	node.publish(rpc.NewHeadsSubscription, node.renderHeader(block))
+}
+
+// confirm has to be called while holding the node mutex
+func (node *Node) confirm(txs []SentTransaction) Block {
+	block := Block{Number: node.state.blockNumber + 1}
+	node.addBlock(block)
+	block = node.state.blocks[block.Number]
+
+	for index, tx := range txs {
+		if tx.Staking {
+			block.StakingTransactions = append(block.StakingTransactions, tx.Hash)
+		} else {
+			block.Transactions = append(block.Transactions, tx.Hash)
+		}
+
+		node.state.receipts[tx.Hash] = Receipt{
+			TransactionHash:  tx.Hash,
+			BlockHash:        block.Hash,
+			BlockNumber:      block.Number,
+			TransactionIndex: uint64(index),
+			Status:           1,
+			GasUsed:          21000,
+			From:             tx.From,
+			To:               tx.To,
+			ShardID:          tx.ShardID,
+			ToShardID:        tx.ToShardID,
+			Staking:          tx.Staking,
+		}
+	}
+
+	node.state.blocks[block.Number] = block
+
+	confirmed := make(map[string]bool)
+	for _, tx := range txs {
+		confirmed[tx.Hash] = true
+	}
+	pending := []SentTransaction{}
+	for _, tx := range node.state.pending {
+		if !confirmed[tx.Hash] {
+			pending = append(pending, tx)
+		}
+	}
+	node.state.pending = pending
+
+	return block
+}
+
+// sortedValidators has to be called while holding the node mutex
+func (node *Node) sortedValidators() []string {
+	addresses := []string{}
+	for addr := range node.state.validators {
+		addresses = append(addresses, addr)
+	}
+	sort.Strings(addresses)
+
+	return addresses
+}
+
+func normalizeAddress(addr string) string {
+	return address.ToBech32(address.Parse(addr))
+}
+
+func hashOf(value string) string {
+	return crypto.Keccak256Hash([]byte(value)).Hex()
+}
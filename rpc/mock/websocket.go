@@ -0,0 +1,226 @@
+package mock
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/gorilla/websocket"
+	"github.com/harmony-one/go-lib/rpc"
+)
+
+var upgrader = websocket.Upgrader{CheckOrigin: func(*http.Request) bool { return true }}
+
+// wsConn - a WebSocket client connected to the mock node
+type wsConn struct {
+	conn          *websocket.Conn
+	outgoing      chan interface{}
+	closed        chan struct{}
+	subscriptions map[string]string // subscriptions - subscription id => subscription name, guarded by the node mutex
+}
+
+type notification struct {
+	JSONRPC string             `json:"jsonrpc"`
+	Method  string             `json:"method"`
+	Params  notificationParams `json:"params"`
+}
+
+type notificationParams struct {
+	Subscription string      `json:"subscription"`
+	Result       interface{} `json:"result"`
+}
+
+var subscriptionID uint64
+
+// SetWebSocketAvailable - enables / disables the WebSocket endpoint, disabling it also drops all connections
+// While disabled, WebSocket handshakes are rejected so clients have to fall back to polling
+func (node *Node) SetWebSocketAvailable(available bool) {
+	node.mutex.Lock()
+	node.wsDisabled = !available
+	node.mutex.Unlock()
+
+	if !available {
+		node.DropWebSocketConnections()
+	}
+}
+
+// DropWebSocketConnections - closes all WebSocket connections, e.g. to simulate a node restart, and returns the number of closed connections
+func (node *Node) DropWebSocketConnections() int {
+	node.mutex.Lock()
+	conns := make([]*wsConn, 0, len(node.wsConns))
+	for conn := range node.wsConns {
+		conns = append(conns, conn)
+	}
+	node.mutex.Unlock()
+
+	for _, conn := range conns {
+		conn.conn.Close()
+	}
+
+	return len(conns)
+}
+
+// Subscriptions - returns the number of active subscriptions for a given name (e.g. "newHeads") or all subscriptions if name is empty
+func (node *Node) Subscriptions(name string) int {
+	node.mutex.Lock()
+	defer node.mutex.Unlock()
+
+	count := 0
+	for conn := range node.wsConns {
+		for _, subscription := range conn.subscriptions {
+			if name == "" || subscription == name {
+				count++
+			}
+		}
+	}
+
+	return count
+}
+
+func (node *Node) serveWebSocket(writer http.ResponseWriter, httpRequest *http.Request) {
+	node.mutex.Lock()
+	disabled := node.wsDisabled
+	node.mutex.Unlock()
+
+	if disabled {
+		writer.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	conn, err := upgrader.Upgrade(writer, httpRequest, nil)
+	if err != nil {
+		return
+	}
+
+	client := &wsConn{
+		conn:          conn,
+		outgoing:      make(chan interface{}, 256),
+		closed:        make(chan struct{}),
+		subscriptions: make(map[string]string),
+	}
+
+	node.mutex.Lock()
+	node.wsConns[client] = true
+	node.mutex.Unlock()
+
+	defer func() {
+		node.mutex.Lock()
+		delete(node.wsConns, client)
+		node.mutex.Unlock()
+
+		close(client.closed)
+		conn.Close()
+	}()
+
+	go client.write()
+
+	for {
+		req := request{}
+		if err := conn.ReadJSON(&req); err != nil {
+			return
+		}
+
+		resp := response{ID: req.ID, JSONRPC: "2.0"}
+
+		switch normalizeMethod(req.Method) {
+		case "subscribe":
+			node.subscribe(client, resp, req.Params)
+			continue
+		case "unsubscribe":
+			resp.Result, resp.Error = node.unsubscribe(client, req.Params)
+		default:
+			var statusCode int
+			resp, statusCode = node.process(req)
+			if statusCode != http.StatusOK {
+				resp.Error = &Error{Code: ErrorCodeServer, Message: http.StatusText(statusCode)}
+			}
+		}
+
+		client.send(resp)
+	}
+}
+
+// subscribe registers a subscription and queues the response while holding the node mutex, so the response is always sent before the first notification
+func (node *Node) subscribe(client *wsConn, resp response, params []json.RawMessage) {
+	name, err := stringParam(params, 0)
+	if err != nil {
+		resp.Error = err
+		client.send(resp)
+		return
+	}
+
+	if name != rpc.NewHeadsSubscription && name != rpc.NewPendingTransactionsSubscription {
+		resp.Error = &Error{Code: ErrorCodeInvalidParams, Message: fmt.Sprintf("no %q subscription in hmy namespace", name)}
+		client.send(resp)
+		return
+	}
+
+	id := fmt.Sprintf("0x%x", atomic.AddUint64(&subscriptionID, 1))
+
+	node.mutex.Lock()
+	defer node.mutex.Unlock()
+
+	client.subscriptions[id] = name
+	resp.Result = id
+	client.send(resp)
+}
+
+func (node *Node) unsubscribe(client *wsConn, params []json.RawMessage) (interface{}, *Error) {
+	id, err := stringParam(params, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	node.mutex.Lock()
+	defer node.mutex.Unlock()
+
+	_, ok := client.subscriptions[id]
+	delete(client.subscriptions, id)
+
+	return ok, nil
+}
+
+// publish has to be called while holding the node mutex
+func (node *Node) publish(name string, result interface{}) {
+	for client := range node.wsConns {
+		for id, subscription := range client.subscriptions {
+			if subscription == name {
+				client.send(notification{JSONRPC: "2.0", Method: rpc.SubscriptionNotificationMethod, Params: notificationParams{Subscription: id, Result: result}})
+			}
+		}
+	}
+}
+
+// send queues a message without blocking, messages for slow clients are dropped once the queue is full
+func (client *wsConn) send(message interface{}) {
+	select {
+	case client.outgoing <- message:
+	case <-client.closed:
+	default:
+	}
+}
+
+func (client *wsConn) write() {
+	for {
+		select {
+		case message := <-client.outgoing:
+			if err := client.conn.WriteJSON(message); err != nil {
+				client.conn.Close()
+				return
+			}
+		case <-client.closed:
+			return
+		}
+	}
+}
+
+func (node *Node) renderHeader(block Block) map[string]interface{} {
+	return map[string]interface{}{
+		"shard-id":          node.ShardID,
+		"block-header-hash": block.Hash,
+		"block-number":      block.Number,
+		"view-id":           block.Number,
+		"epoch":             block.Epoch,
+	}
+}
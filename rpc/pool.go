@@ -0,0 +1,349 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	goSdkRPC "github.com/harmony-one/go-sdk/pkg/rpc"
+)
+
+// Supported node selection strategies
+const (
+	SelectionRoundRobin = "round-robin"
+	SelectionLatency    = "latency"
+)
+
+var (
+	// ErrEmptyNodePool is returned when a node pool doesn't contain any nodes
+	ErrEmptyNodePool = errors.New("the node pool doesn't contain any nodes")
+
+	// DefaultEjectionTime - time a node is ejected from a pool for after reaching the maximum number of consecutive failures
+	DefaultEjectionTime = 30 * time.Second
+
+	// DefaultMaxFailures - number of consecutive failures after which a node is ejected
+	DefaultMaxFailures = 3
+)
+
+// NodePool - a pool of endpoints serving the same shard, used for load balancing and failover
+// Nodes failing with connection / rate limit / server errors are temporarily ejected and skipped until their ejection expires
+type NodePool struct {
+	Selection    string        // Selection - SelectionRoundRobin (default) or SelectionLatency (random, weighted by inverse latency)
+	EjectionTime time.Duration // EjectionTime - defaults to DefaultEjectionTime
+	MaxFailures  int           // MaxFailures - defaults to DefaultMaxFailures
+
+	mutex sync.Mutex
+	nodes []*poolNode
+	next  int
+	rand  *rand.Rand
+}
+
+// NodeStatus - snapshot of the health of a node in a pool
+type NodeStatus struct {
+	Node         string
+	Healthy      bool
+	Latency      time.Duration // Latency - exponentially weighted moving average of the request latency
+	Failures     int           // Failures - consecutive failures
+	EjectedUntil time.Time
+	Requests     uint64
+	Errors       uint64
+}
+
+type poolNode struct {
+	url          string
+	latency      time.Duration
+	failures     int
+	ejectedUntil time.Time
+	requests     uint64
+	errors       uint64
+}
+
+// NewNodePool - creates a new node pool for the given node urls using a given selection strategy
+func NewNodePool(nodes []string, selection string) *NodePool {
+	pool := &NodePool{
+		Selection: selection,
+		rand:      rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+
+	seen := make(map[string]bool)
+	for _, node := range nodes {
+		if node != "" && !seen[node] {
+			seen[node] = true
+			pool.nodes = append(pool.nodes, &poolNode{url: node})
+		}
+	}
+
+	return pool
+}
+
+// Select - selects the node to use for the next request
+func (pool *NodePool) Select() (string, error) {
+	candidates, err := pool.Candidates()
+	if err != nil {
+		return "", err
+	}
+
+	return candidates[0], nil
+}
+
+// Candidates - returns all nodes in the order they should be tried: the selected node first, followed by the other healthy nodes and finally the ejected nodes (soonest to recover first)
+func (pool *NodePool) Candidates() ([]string, error) {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	if len(pool.nodes) == 0 {
+		return nil, ErrEmptyNodePool
+	}
+
+	now := time.Now()
+	healthy := []*poolNode{}
+	ejected := []*poolNode{}
+
+	for i := 0; i < len(pool.nodes); i++ {
+		node := pool.nodes[(pool.next+i)%len(pool.nodes)]
+		if now.Before(node.ejectedUntil) {
+			ejected = append(ejected, node)
+		} else {
+			healthy = append(healthy, node)
+		}
+	}
+	pool.next = (pool.next + 1) % len(pool.nodes)
+
+	if pool.Selection == SelectionLatency && len(healthy) > 1 {
+		selected := pool.weightedByLatency(healthy)
+		healthy[0], healthy[selected] = healthy[selected], healthy[0]
+	}
+
+	sort.SliceStable(ejected, func(i, j int) bool { return ejected[i].ejectedUntil.Before(ejected[j].ejectedUntil) })
+
+	candidates := []string{}
+	for _, node := range append(healthy, ejected...) {
+		candidates = append(candidates, node.url)
+	}
+
+	return candidates, nil
+}
+
+// Do - executes fn against the selected node, failing over to the next candidate on connection / rate limit / server errors
+func (pool *NodePool) Do(ctx context.Context, fn func(ctx context.Context, node string) ([]byte, error)) ([]byte, error) {
+	candidates, err := pool.Candidates()
+	if err != nil {
+		return nil, err
+	}
+
+	var lastErr error
+	for _, node := range candidates {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		started := time.Now()
+		body, err := fn(ctx, node)
+		if err == nil {
+			pool.ReportSuccess(node, time.Since(started))
+			return body, nil
+		}
+
+		if !IsRetryable(err) {
+			return nil, err
+		}
+
+		pool.ReportFailure(node)
+		lastErr = err
+	}
+
+	return nil, lastErr
+}
+
+// DoOnce - executes fn against the selected node without failing over, used for requests that mustn't be sent twice
+func (pool *NodePool) DoOnce(ctx context.Context, fn func(ctx context.Context, node string) ([]byte, error)) ([]byte, error) {
+	node, err := pool.Select()
+	if err != nil {
+		return nil, err
+	}
+
+	started := time.Now()
+	body, err := fn(ctx, node)
+	if err != nil {
+		if IsRetryable(err) {
+			pool.ReportFailure(node)
+		}
+		return nil, err
+	}
+
+	pool.ReportSuccess(node, time.Since(started))
+
+	return body, nil
+}
+
+// ReportSuccess - records a successful request for a given node
+func (pool *NodePool) ReportSuccess(node string, latency time.Duration) {
+	pool.update(node, func(n *poolNode) {
+		n.requests++
+		n.failures = 0
+		n.ejectedUntil = time.Time{}
+		if n.latency == 0 {
+			n.latency = latency
+		} else {
+			n.latency = (n.latency*7 + latency*3) / 10
+		}
+	})
+}
+
+// ReportFailure - records a failed request for a given node, ejecting it once it reaches the maximum number of consecutive failures
+func (pool *NodePool) ReportFailure(node string) {
+	pool.update(node, func(n *poolNode) {
+		n.requests++
+		n.errors++
+		n.failures++
+		if n.failures >= pool.maxFailures() {
+			n.ejectedUntil = time.Now().Add(pool.ejectionTime())
+		}
+	})
+}
+
+// Eject - ejects a node for a given duration
+func (pool *NodePool) Eject(node string, duration time.Duration) {
+	pool.update(node, func(n *poolNode) {
+		n.ejectedUntil = time.Now().Add(duration)
+	})
+}
+
+// HealthCheck - probes every node in the pool (using the current block number) and updates its health
+func (pool *NodePool) HealthCheck(ctx context.Context) {
+	var wg sync.WaitGroup
+
+	for _, status := range pool.Status() {
+		wg.Add(1)
+		go func(node string) {
+			defer wg.Done()
+
+			// A single attempt per probe, retrying would hide failing nodes and skew the latency samples
+			client := NewClientWithRetry(node, nil)
+			started := time.Now()
+			if _, err := client.RawRequest(ctx, goSdkRPC.Method.BlockNumber, []interface{}{}); err != nil {
+				if ctx.Err() == nil {
+					pool.ReportFailure(node)
+				}
+				return
+			}
+			pool.ReportSuccess(node, time.Since(started))
+		}(status.Node)
+	}
+
+	wg.Wait()
+}
+
+// StartHealthChecks - runs HealthCheck every interval until the context is done
+func (pool *NodePool) StartHealthChecks(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				checkCtx, cancel := context.WithTimeout(ctx, interval)
+				pool.HealthCheck(checkCtx)
+				cancel()
+			}
+		}
+	}()
+}
+
+// Status - returns a snapshot of the health of every node in the pool
+func (pool *NodePool) Status() []NodeStatus {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	now := time.Now()
+	statuses := []NodeStatus{}
+	for _, node := range pool.nodes {
+		statuses = append(statuses, NodeStatus{
+			Node:         node.url,
+			Healthy:      !now.Before(node.ejectedUntil),
+			Latency:      node.latency,
+			Failures:     node.failures,
+			EjectedUntil: node.ejectedUntil,
+			Requests:     node.requests,
+			Errors:       node.errors,
+		})
+	}
+
+	return statuses
+}
+
+// Nodes - returns the urls of all nodes in the pool
+func (pool *NodePool) Nodes() []string {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	nodes := []string{}
+	for _, node := range pool.nodes {
+		nodes = append(nodes, node.url)
+	}
+
+	return nodes
+}
+
+func (pool *NodePool) update(url string, fn func(n *poolNode)) {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	for _, node := range pool.nodes {
+		if node.url == url {
+			fn(node)
+			return
+		}
+	}
+}
+
+// weightedByLatency has to be called while holding the pool mutex
+// Nodes without a latency measurement are always preferred so that they get measured
+func (pool *NodePool) weightedByLatency(nodes []*poolNode) int {
+	total := 0.0
+	weights := make([]float64, len(nodes))
+
+	for i, node := range nodes {
+		if node.latency <= 0 {
+			return i
+		}
+		weights[i] = 1 / node.latency.Seconds()
+		total += weights[i]
+	}
+
+	if pool.rand == nil {
+		pool.rand = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+
+	target := pool.rand.Float64() * total
+	for i, weight := range weights {
+		target -= weight
+		if target <= 0 {
+			return i
+		}
+	}
+
+	return len(nodes) - 1
+}
+
+func (pool *NodePool) maxFailures() int {
+	if pool.MaxFailures > 0 {
+		return pool.MaxFailures
+	}
+
+	return DefaultMaxFailures
+}
+
+func (pool *NodePool) ejectionTime() time.Duration {
+	if pool.EjectionTime > 0 {
+		return pool.EjectionTime
+	}
+
+	return DefaultEjectionTime
+}
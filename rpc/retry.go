@@ -0,0 +1,158 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	commonTypes "github.com/harmony-one/go-lib/network/types/common"
+	goSdkRPC "github.com/harmony-one/go-sdk/pkg/rpc"
+)
+
+var (
+	// DefaultRetry - retry policy used by clients created using NewClient, defaults to 3 attempts with an exponential backoff (1s, 2s)
+	DefaultRetry = commonTypes.Retry{
+		Attempts: 3,
+		Wait:     1,
+		Backoff:  commonTypes.BackoffExponential,
+		MaxWait:  5,
+		Jitter:   0.1,
+	}
+
+	retryableRPCMessages = []string{
+		"rate limit",
+		"too many requests",
+		"timeout",
+		"temporarily unavailable",
+	}
+)
+
+// Codes used by the Harmony RPC endpoints, see go-sdk's pkg/rpc/methods.go
+const (
+	rpcCodeInWarmup     = -28
+	rpcCodeGenericError = -32000
+)
+
+// ExecuteWithRetry - executes fn until it succeeds, returns a non retryable error or the retry policy is exhausted
+func ExecuteWithRetry(ctx context.Context, retry *commonTypes.Retry, fn func(ctx context.Context) error) (err error) {
+	attempts := 1
+	if retry != nil && retry.Attempts > 1 {
+		attempts = retry.Attempts
+	}
+
+	for attempt := 0; attempt < attempts; attempt++ {
+		if err = fn(ctx); err == nil {
+			return nil
+		}
+
+		if ctx.Err() != nil || !IsRetryable(err) || attempt == attempts-1 {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(RetryDelay(retry, attempt)):
+		}
+	}
+
+	return err
+}
+
+// RetryDelay - calculates the wait time before the next retry given the zero based attempt that just failed
+func RetryDelay(retry *commonTypes.Retry, attempt int) time.Duration {
+	if retry == nil {
+		return 0
+	}
+
+	wait := time.Duration(retry.Wait) * time.Second
+	maxWait := time.Duration(retry.MaxWait) * time.Second
+
+	if retry.Backoff == commonTypes.BackoffExponential {
+		for i := 0; i < attempt; i++ {
+			wait *= 2
+			if (maxWait > 0 && wait >= maxWait) || wait > time.Hour {
+				break
+			}
+		}
+	}
+
+	if maxWait > 0 && wait > maxWait {
+		wait = maxWait
+	}
+
+	if retry.Jitter > 0 && wait > 0 {
+		wait += time.Duration(rand.Float64() * retry.Jitter * float64(wait))
+	}
+
+	return wait
+}
+
+// IsRetryable - classifies whether a given error is transient (connection issues, rate limiting etc.) and worth retrying
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var statusError *HTTPStatusError
+	if errors.As(err, &statusError) {
+		switch statusError.StatusCode {
+		case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		default:
+			return false
+		}
+	}
+
+	var rpcError RPCError
+	if errors.As(err, &rpcError) {
+		switch rpcError.Code {
+		case rpcCodeInWarmup:
+			return true
+		case rpcCodeGenericError:
+			message := strings.ToLower(rpcError.Message)
+			for _, retryableMessage := range retryableRPCMessages {
+				if strings.Contains(message, retryableMessage) {
+					return true
+				}
+			}
+			return false
+		default:
+			// invalid params, method not found, rejected txs etc. will fail the same way again
+			return false
+		}
+	}
+
+	// Only transport errors (connection refused/reset, timeouts, EOF etc.) are transient, decode errors and the like will fail the same way again
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	var urlError *url.Error
+	if errors.As(err, &urlError) {
+		return true
+	}
+
+	var netError net.Error
+	return errors.As(err, &netError)
+}
+
+// IsSubmission - returns true for methods submitting txs, these are never retried or failed over since the tx might already have reached the pool
+func IsSubmission(method string) bool {
+	switch method {
+	case goSdkRPC.Method.SendRawTransaction, goSdkRPC.Method.SendRawStakingTransaction:
+		return true
+	default:
+		return false
+	}
+}
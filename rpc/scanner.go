@@ -0,0 +1,203 @@
+package rpc
+
+import (
+	"context"
+)
+
+var (
+	// DefaultScanConcurrency - number of block batches fetched simultaneously by a BlockScanner
+	DefaultScanConcurrency = 4
+
+	// DefaultScanBatchSize - number of blocks fetched per request by a BlockScanner
+	DefaultScanBatchSize = 10
+)
+
+// ScannedBlock - the result for a single block in a range, either Block or Error is set
+type ScannedBlock struct {
+	BlockNumber uint64
+	Block       *Block
+	Error       error
+}
+
+// ScannedTransaction - a transaction found while scanning a block range, either Transaction or StakingTransaction is set unless Error is set
+type ScannedTransaction struct {
+	BlockNumber        uint64
+	Block              *Block
+	Transaction        *BlockTransaction
+	StakingTransaction *BlockStakingTransaction
+	Error              error
+}
+
+// BlockScanner - concurrently fetches block ranges while delivering the blocks in order
+type BlockScanner struct {
+	Client      *Client
+	ShardID     uint32
+	Options     BlockOptions
+	Concurrency int // Concurrency - number of batches fetched simultaneously, defaults to DefaultScanConcurrency
+	BatchSize   int // BatchSize - number of blocks per request, defaults to DefaultScanBatchSize
+}
+
+// NewBlockScanner - creates a new scanner for a given client and shard, fetching full transactions
+func NewBlockScanner(client *Client, shardID uint32) *BlockScanner {
+	return &BlockScanner{
+		Client:  client,
+		ShardID: shardID,
+		Options: BlockOptions{FullTx: true},
+	}
+}
+
+// Scan - fetches the blocks from start to end (inclusive) and delivers them in ascending order on the returned channel
+// Blocks that couldn't be fetched are delivered with an error, the channel is closed once the range has been delivered or the context is done
+func (scanner *BlockScanner) Scan(ctx context.Context, start uint64, end uint64) <-chan ScannedBlock {
+	results := make(chan ScannedBlock)
+
+	concurrency := scanner.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultScanConcurrency
+	}
+
+	batchSize := uint64(scanner.BatchSize)
+	if batchSize == 0 {
+		batchSize = uint64(DefaultScanBatchSize)
+	}
+
+	// Every batch gets its own result channel, the channels are queued in order so that batches can be
+	// fetched concurrently while still being delivered in order. A batch holds one of the slots while it's
+	// being fetched, so at most concurrency batches are in flight, and the queue size bounds the number of
+	// fetched batches waiting to be delivered.
+	queue := make(chan chan []ScannedBlock, concurrency)
+	slots := make(chan struct{}, concurrency)
+
+	go func() {
+		defer close(queue)
+
+		for batchStart := start; batchStart <= end; batchStart += batchSize {
+			batchEnd := batchStart + batchSize - 1
+			if batchEnd > end || batchEnd < batchStart {
+				batchEnd = end
+			}
+
+			select {
+			case slots <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+
+			batch := make(chan []ScannedBlock, 1)
+			select {
+			case queue <- batch:
+			case <-ctx.Done():
+				<-slots
+				return
+			}
+
+			go func(batchStart uint64, batchEnd uint64) {
+				defer func() { <-slots }()
+				batch <- scanner.fetch(ctx, batchStart, batchEnd)
+			}(batchStart, batchEnd)
+
+			if batchEnd == end {
+				return
+			}
+		}
+	}()
+
+	go func() {
+		defer close(results)
+
+		for batch := range queue {
+			var blocks []ScannedBlock
+			select {
+			case blocks = <-batch:
+			case <-ctx.Done():
+				return
+			}
+
+			for _, block := range blocks {
+				select {
+				case results <- block:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return results
+}
+
+// Collect - fetches the blocks from start to end (inclusive), returning the blocks in order and the first error encountered
+func (scanner *BlockScanner) Collect(ctx context.Context, start uint64, end uint64) ([]*Block, error) {
+	blocks := []*Block{}
+
+	for result := range scanner.Scan(ctx, start, end) {
+		if result.Error != nil {
+			return blocks, result.Error
+		}
+		blocks = append(blocks, result.Block)
+	}
+
+	return blocks, ctx.Err()
+}
+
+// Transactions - iterates over all regular and staking transactions in the blocks from start to end (inclusive), in block and transaction order
+// Blocks that couldn't be fetched are delivered as a single result with an error
+func (scanner *BlockScanner) Transactions(ctx context.Context, start uint64, end uint64) <-chan ScannedTransaction {
+	results := make(chan ScannedTransaction)
+
+	go func() {
+		defer close(results)
+
+		send := func(result ScannedTransaction) bool {
+			select {
+			case results <- result:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		for scanned := range scanner.Scan(ctx, start, end) {
+			if scanned.Error != nil {
+				if !send(ScannedTransaction{BlockNumber: scanned.BlockNumber, Error: scanned.Error}) {
+					return
+				}
+				continue
+			}
+
+			block := scanned.Block
+			for index := range block.Transactions {
+				if !send(ScannedTransaction{BlockNumber: scanned.BlockNumber, Block: block, Transaction: &block.Transactions[index]}) {
+					return
+				}
+			}
+
+			for index := range block.StakingTransactions {
+				if !send(ScannedTransaction{BlockNumber: scanned.BlockNumber, Block: block, StakingTransaction: &block.StakingTransactions[index]}) {
+					return
+				}
+			}
+		}
+	}()
+
+	return results
+}
+
+func (scanner *BlockScanner) fetch(ctx context.Context, start uint64, end uint64) []ScannedBlock {
+	blocks, err := GetBlocksWithContext(ctx, scanner.Client, start, end, scanner.Options)
+	if err != nil {
+		blocks = make([]ScannedBlock, 0, end-start+1)
+		for blockNumber := start; blockNumber <= end; blockNumber++ {
+			blocks = append(blocks, ScannedBlock{BlockNumber: blockNumber, Error: err})
+		}
+		return blocks
+	}
+
+	for _, block := range blocks {
+		if block.Block != nil {
+			block.Block.ShardID = scanner.ShardID
+		}
+	}
+
+	return blocks
+}
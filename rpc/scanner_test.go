@@ -0,0 +1,128 @@
+package rpc_test
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/harmony-one/go-lib/rpc"
+	"github.com/harmony-one/go-lib/rpc/mock"
+)
+
+// inFlightTransport - counts the HTTP requests in flight to track the maximum number of concurrent requests
+type inFlightTransport struct {
+	mutex    sync.Mutex
+	inFlight int
+	max      int
+}
+
+func (transport *inFlightTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	transport.mutex.Lock()
+	transport.inFlight++
+	if transport.inFlight > transport.max {
+		transport.max = transport.inFlight
+	}
+	transport.mutex.Unlock()
+
+	defer func() {
+		transport.mutex.Lock()
+		transport.inFlight--
+		transport.mutex.Unlock()
+	}()
+
+	return http.DefaultTransport.RoundTrip(request)
+}
+
+func (transport *inFlightTransport) maxInFlight() int {
+	transport.mutex.Lock()
+	defer transport.mutex.Unlock()
+
+	return transport.max
+}
+
+func newScanNode(blockCount uint64) *mock.Node {
+	node := mock.NewNode()
+	for blockNumber := uint64(1); blockNumber <= blockCount; blockNumber++ {
+		node.AddBlock(mock.Block{Number: blockNumber})
+	}
+
+	return node
+}
+
+func TestBlockScannerDeliversInOrder(t *testing.T) {
+	node := newScanNode(25)
+	defer node.Close()
+
+	// Make the batches overlap
+	node.SetLatency("getBlockByNumber", 5*time.Millisecond)
+
+	transport := &inFlightTransport{}
+	client := rpc.NewClientWithRetry(node.URL, nil)
+	client.HTTPClient = &http.Client{Transport: transport}
+
+	scanner := rpc.NewBlockScanner(client, 0)
+	scanner.Concurrency = 3
+	scanner.BatchSize = 4
+
+	blocks, err := scanner.Collect(context.Background(), 1, 25)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(blocks) != 25 {
+		t.Fatalf("expected 25 blocks, got %d", len(blocks))
+	}
+
+	for index, block := range blocks {
+		if block.Number != uint64(index+1) {
+			t.Fatalf("expected block %d at position %d, got block %d", index+1, index, block.Number)
+		}
+	}
+
+	if max := transport.maxInFlight(); max < 2 || max > scanner.Concurrency {
+		t.Errorf("expected between 2 and %d batches in flight, got %d", scanner.Concurrency, max)
+	}
+}
+
+func TestBlockScannerReportsErrors(t *testing.T) {
+	node := newScanNode(12)
+	defer node.Close()
+
+	node.FailNextHTTP("getBlockByNumber", 1, http.StatusInternalServerError)
+
+	scanner := rpc.NewBlockScanner(rpc.NewClientWithRetry(node.URL, nil), 0)
+	scanner.Concurrency = 2
+	scanner.BatchSize = 4
+
+	expected := uint64(1)
+	failed := []uint64{}
+	for result := range scanner.Scan(context.Background(), 1, 12) {
+		if result.BlockNumber != expected {
+			t.Fatalf("expected block %d, got block %d", expected, result.BlockNumber)
+		}
+		expected++
+
+		if result.Error != nil {
+			failed = append(failed, result.BlockNumber)
+		} else if result.Block == nil || result.Block.Number != result.BlockNumber {
+			t.Errorf("expected block %d, got %+v", result.BlockNumber, result.Block)
+		}
+	}
+
+	if expected != 13 {
+		t.Errorf("expected all 12 blocks to be delivered, got %d", expected-1)
+	}
+
+	// The failed request fetched a whole batch, all of its blocks are reported with the error
+	if len(failed) != 4 || (failed[0]-1)%4 != 0 || failed[3] != failed[0]+3 {
+		t.Errorf("expected the blocks of a single batch to fail, got %v", failed)
+	}
+
+	node.FailNextHTTP("getBlockByNumber", 1, http.StatusInternalServerError)
+
+	if _, err := scanner.Collect(context.Background(), 1, 12); err == nil {
+		t.Error("expected Collect to return the error")
+	}
+}
@@ -0,0 +1,523 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+	goSdkCommon "github.com/harmony-one/go-sdk/pkg/common"
+	goSdkRPC "github.com/harmony-one/go-sdk/pkg/rpc"
+)
+
+const (
+	// SubscriptionNotificationMethod - method used by the node to push subscription notifications
+	SubscriptionNotificationMethod = "hmy_subscription"
+
+	// NewHeadsSubscription - subscription delivering the header of every new block
+	NewHeadsSubscription = "newHeads"
+
+	// NewPendingTransactionsSubscription - subscription delivering the hash of every transaction added to the pool
+	NewPendingTransactionsSubscription = "newPendingTransactions"
+
+	// SourceWebSocket - the header / transaction was pushed by the node over a WebSocket subscription
+	SourceWebSocket = "websocket"
+
+	// SourcePolling - the header / transaction was fetched by polling the node's HTTP endpoint
+	SourcePolling = "polling"
+
+	// maxSeenTransactions - maximum number of pending transaction hashes remembered to avoid duplicates
+	maxSeenTransactions = 10000
+
+	// wsPortOffset - difference between the WebSocket and HTTP ports of a Harmony node (9500 vs 9800)
+	wsPortOffset = 300
+)
+
+var (
+	// DefaultSubscriptionPollInterval - interval used to poll the node when the WebSocket endpoint isn't available
+	DefaultSubscriptionPollInterval = 2 * time.Second
+
+	// DefaultReconnectWait - initial wait before reconnecting a dropped WebSocket connection, doubled for every failed attempt
+	DefaultReconnectWait = time.Second
+
+	// DefaultMaxReconnectWait - maximum wait between WebSocket reconnection attempts
+	DefaultMaxReconnectWait = 30 * time.Second
+
+	// DefaultPingInterval - interval used to ping the node to detect dead WebSocket connections
+	DefaultPingInterval = 15 * time.Second
+
+	// ErrSubscriptionClosed is returned when the node closes a subscription
+	ErrSubscriptionClosed = errors.New("subscription closed by the node")
+)
+
+// Header - a block header as delivered by the newHeads subscription
+type Header struct {
+	ShardID     uint32    `json:"shard-id" yaml:"shardID"`
+	Hash        string    `json:"block-header-hash" yaml:"hash"`
+	BlockNumber uint64    `json:"block-number" yaml:"blockNumber"`
+	ViewID      uint64    `json:"view-id" yaml:"viewID"`
+	Epoch       uint64    `json:"epoch" yaml:"epoch"`
+	ReceivedAt  time.Time `json:"-" yaml:"-"`
+	Source      string    `json:"-" yaml:"-"` // Source - SourceWebSocket or SourcePolling
+}
+
+// PendingTransaction - a transaction hash as delivered by the newPendingTransactions subscription
+type PendingTransaction struct {
+	Hash       string
+	ReceivedAt time.Time
+	Source     string // Source - SourceWebSocket or SourcePolling
+}
+
+// Subscriber - subscribes to new heads / pending transactions using the node's WebSocket endpoint
+// Dropped connections are reconnected automatically, while the WebSocket endpoint isn't available the node is polled using Client instead
+type Subscriber struct {
+	Client           *Client // Client - HTTP client used for polling and filling gaps after reconnecting
+	URL              string  // URL - WebSocket endpoint, derived from the client's node using WebSocketURL if empty
+	ShardID          uint32
+	Polling          bool          // Polling - fall back to polling while the WebSocket endpoint isn't available
+	PollInterval     time.Duration // PollInterval - defaults to DefaultSubscriptionPollInterval
+	ReconnectWait    time.Duration // ReconnectWait - defaults to DefaultReconnectWait
+	MaxReconnectWait time.Duration // MaxReconnectWait - defaults to DefaultMaxReconnectWait
+	PingInterval     time.Duration // PingInterval - defaults to DefaultPingInterval
+	Dialer           *websocket.Dialer
+	OnError          func(err error) // OnError - called for connection, subscription and polling errors, the subscription keeps running
+}
+
+type subscriptionMessage struct {
+	ID     *uint64             `json:"id"`
+	Method string              `json:"method"`
+	Result json.RawMessage     `json:"result"`
+	Error  *RPCError           `json:"error,omitempty"`
+	Params *subscriptionResult `json:"params"`
+}
+
+type subscriptionResult struct {
+	Subscription string          `json:"subscription"`
+	Result       json.RawMessage `json:"result"`
+}
+
+// NewSubscriber - creates a new subscriber for a given client and shard with the polling fallback enabled
+func NewSubscriber(client *Client, shardID uint32) *Subscriber {
+	return &Subscriber{
+		Client:  client,
+		ShardID: shardID,
+		Polling: true,
+	}
+}
+
+// WebSocketURL - derives the WebSocket endpoint of a node from its HTTP endpoint
+// e.g. https://api.s0.t.hmny.io -> wss://ws.s0.t.hmny.io and http://localhost:9500 -> ws://localhost:9800
+func WebSocketURL(node string) (string, error) {
+	parsed, err := url.Parse(node)
+	if err != nil {
+		return "", err
+	}
+
+	switch parsed.Scheme {
+	case "http":
+		parsed.Scheme = "ws"
+	case "https":
+		parsed.Scheme = "wss"
+	case "ws", "wss":
+		return node, nil
+	default:
+		return "", fmt.Errorf("can't derive a WebSocket endpoint for %s", node)
+	}
+
+	host := parsed.Hostname()
+	if strings.HasPrefix(host, "api.") {
+		host = "ws." + strings.TrimPrefix(host, "api.")
+	}
+
+	if port := parsed.Port(); port != "" {
+		number, err := strconv.Atoi(port)
+		if err != nil {
+			return "", err
+		}
+		host = net.JoinHostPort(host, strconv.Itoa(number+wsPortOffset))
+	} else if strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+
+	parsed.Host = host
+
+	return parsed.String(), nil
+}
+
+// SubscribeNewHeads - delivers the header of every new block in order, starting with the next block produced
+// Blocks missed while reconnecting are fetched using the client so that no block number is skipped
+// The channel is closed once the context is done
+func (subscriber *Subscriber) SubscribeNewHeads(ctx context.Context) <-chan Header {
+	headers := make(chan Header)
+	var last uint64
+
+	deliver := func(header Header) bool {
+		if last != 0 && header.BlockNumber <= last {
+			return true
+		}
+
+		if last != 0 && header.BlockNumber > last+1 {
+			if !subscriber.fillGap(ctx, headers, last+1, header.BlockNumber-1) {
+				return false
+			}
+		}
+
+		select {
+		case headers <- header:
+			last = header.BlockNumber
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	notify := func(result json.RawMessage) bool {
+		header := Header{}
+		if err := json.Unmarshal(result, &header); err != nil {
+			subscriber.reportError(fmt.Errorf("invalid header notification: %w", err))
+			return true
+		}
+		header.ReceivedAt = time.Now()
+		header.Source = SourceWebSocket
+
+		return deliver(header)
+	}
+
+	poll := func(ctx context.Context) error {
+		current, err := GetCurrentBlockNumberWithContext(ctx, subscriber.Client)
+		if err != nil {
+			return err
+		}
+
+		if last != 0 && current <= last {
+			return nil
+		}
+
+		block, err := GetBlockWithContext(ctx, subscriber.Client, current, BlockOptions{})
+		if err != nil {
+			return err
+		}
+
+		// deliver fills the gap between the last delivered and the current block
+		deliver(subscriber.header(block))
+
+		return nil
+	}
+
+	go func() {
+		defer close(headers)
+		subscriber.run(ctx, NewHeadsSubscription, notify, poll)
+	}()
+
+	return headers
+}
+
+// SubscribePendingTransactions - delivers the hash of every transaction added to the node's transaction pool
+// Transactions added while reconnecting can be missed, the channel is closed once the context is done
+func (subscriber *Subscriber) SubscribePendingTransactions(ctx context.Context) <-chan PendingTransaction {
+	transactions := make(chan PendingTransaction)
+	seen := make(map[string]bool)
+
+	deliver := func(hash string, source string) bool {
+		select {
+		case transactions <- PendingTransaction{Hash: hash, ReceivedAt: time.Now(), Source: source}:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	notify := func(result json.RawMessage) bool {
+		var hash string
+		if err := json.Unmarshal(result, &hash); err != nil {
+			subscriber.reportError(fmt.Errorf("invalid pending transaction notification: %w", err))
+			return true
+		}
+
+		// Remember the hashes so they aren't delivered again when falling back to polling
+		if len(seen) >= maxSeenTransactions {
+			seen = make(map[string]bool)
+		}
+		seen[hash] = true
+
+		return deliver(hash, SourceWebSocket)
+	}
+
+	poll := func(ctx context.Context) error {
+		hashes, err := pendingTransactionHashes(ctx, subscriber.Client)
+		if err != nil {
+			return err
+		}
+
+		// Only the hashes of the current pool are kept to keep the set from growing indefinitely
+		current := make(map[string]bool, len(hashes))
+		for _, hash := range hashes {
+			current[hash] = true
+			if !seen[hash] && !deliver(hash, SourcePolling) {
+				return ctx.Err()
+			}
+		}
+		seen = current
+
+		return nil
+	}
+
+	go func() {
+		defer close(transactions)
+		subscriber.run(ctx, NewPendingTransactionsSubscription, notify, poll)
+	}()
+
+	return transactions
+}
+
+// run keeps a WebSocket subscription alive until the context is done, polling in between reconnection attempts
+func (subscriber *Subscriber) run(ctx context.Context, name string, notify func(result json.RawMessage) bool, poll func(ctx context.Context) error) {
+	wait := subscriber.reconnectWait()
+
+	for ctx.Err() == nil {
+		subscribed, err := subscriber.stream(ctx, name, notify)
+		if ctx.Err() != nil {
+			return
+		}
+		subscriber.reportError(err)
+
+		if subscribed {
+			wait = subscriber.reconnectWait()
+		}
+
+		subscriber.pollFor(ctx, wait, poll)
+
+		if wait *= 2; wait > subscriber.maxReconnectWait() {
+			wait = subscriber.maxReconnectWait()
+		}
+	}
+}
+
+// pollFor polls the node until the given wait time has passed, or just waits if polling is disabled
+func (subscriber *Subscriber) pollFor(ctx context.Context, wait time.Duration, poll func(ctx context.Context) error) {
+	deadline := time.NewTimer(wait)
+	defer deadline.Stop()
+
+	if !subscriber.Polling || subscriber.Client == nil {
+		select {
+		case <-ctx.Done():
+		case <-deadline.C:
+		}
+		return
+	}
+
+	interval := subscriber.PollInterval
+	if interval <= 0 {
+		interval = DefaultSubscriptionPollInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := poll(ctx); err != nil && ctx.Err() == nil {
+			subscriber.reportError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-deadline.C:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// stream subscribes over a new WebSocket connection and delivers notifications until the connection fails or the context is done
+// The returned bool reports whether the subscription had been established
+func (subscriber *Subscriber) stream(ctx context.Context, name string, notify func(result json.RawMessage) bool) (bool, error) {
+	endpoint, err := subscriber.endpoint()
+	if err != nil {
+		return false, err
+	}
+
+	dialer := subscriber.Dialer
+	if dialer == nil {
+		dialer = websocket.DefaultDialer
+	}
+
+	conn, _, err := dialer.DialContext(ctx, endpoint, nil)
+	if err != nil {
+		return false, err
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+
+	pingInterval := subscriber.PingInterval
+	if pingInterval <= 0 {
+		pingInterval = DefaultPingInterval
+	}
+
+	conn.SetReadDeadline(time.Now().Add(2 * pingInterval))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(2 * pingInterval))
+	})
+
+	go func() {
+		ticker := time.NewTicker(pingInterval)
+		defer ticker.Stop()
+		defer conn.Close()
+
+		for {
+			select {
+			case <-ctx.Done():
+				conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(time.Second))
+				return
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(pingInterval)); err != nil {
+					return
+				}
+			}
+		}
+	}()
+
+	id := atomic.AddUint64(&requestID, 1)
+	request := batchRequest{JSONRPC: goSdkCommon.JSONRPCVersion, ID: id, Method: goSdkRPC.Method.Subscribe, Params: []interface{}{name}}
+	if err := conn.WriteJSON(request); err != nil {
+		return false, err
+	}
+
+	subscriptionID := ""
+	for {
+		message := subscriptionMessage{}
+		if err := conn.ReadJSON(&message); err != nil {
+			if websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				err = ErrSubscriptionClosed
+			}
+			return subscriptionID != "", err
+		}
+
+		// Any message proves the connection is alive, not just pongs
+		conn.SetReadDeadline(time.Now().Add(2 * pingInterval))
+
+		switch {
+		case message.ID != nil && *message.ID == id:
+			if message.Error != nil {
+				return false, *message.Error
+			}
+			if err := json.Unmarshal(message.Result, &subscriptionID); err != nil || subscriptionID == "" {
+				return false, fmt.Errorf("invalid %s subscription response: %s", name, string(message.Result))
+			}
+		case message.Method == SubscriptionNotificationMethod && message.Params != nil && message.Params.Subscription == subscriptionID:
+			if !notify(message.Params.Result) {
+				return true, ctx.Err()
+			}
+		}
+	}
+}
+
+// fillGap delivers the headers of the blocks missed while the connection was down
+func (subscriber *Subscriber) fillGap(ctx context.Context, headers chan<- Header, start uint64, end uint64) bool {
+	if subscriber.Client == nil {
+		return true
+	}
+
+	blocks, err := GetBlocksWithContext(ctx, subscriber.Client, start, end, BlockOptions{})
+	if err != nil {
+		subscriber.reportError(fmt.Errorf("failed to fetch the missed blocks %d - %d: %w", start, end, err))
+		return ctx.Err() == nil
+	}
+
+	for _, result := range blocks {
+		if result.Error != nil {
+			subscriber.reportError(result.Error)
+			continue
+		}
+
+		select {
+		case headers <- subscriber.header(result.Block):
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	return true
+}
+
+// header converts a block fetched using the client to a header
+func (subscriber *Subscriber) header(block *Block) Header {
+	block.ShardID = subscriber.ShardID
+	header := block.Header()
+	header.ReceivedAt = time.Now()
+	header.Source = SourcePolling
+
+	return header
+}
+
+func (subscriber *Subscriber) endpoint() (string, error) {
+	if subscriber.URL != "" {
+		return subscriber.URL, nil
+	}
+
+	if subscriber.Client == nil || subscriber.Client.Node == "" {
+		return "", errors.New("can't subscribe without a WebSocket endpoint or node")
+	}
+
+	return WebSocketURL(subscriber.Client.Node)
+}
+
+func (subscriber *Subscriber) reconnectWait() time.Duration {
+	if subscriber.ReconnectWait > 0 {
+		return subscriber.ReconnectWait
+	}
+
+	return DefaultReconnectWait
+}
+
+func (subscriber *Subscriber) maxReconnectWait() time.Duration {
+	if subscriber.MaxReconnectWait > 0 {
+		return subscriber.MaxReconnectWait
+	}
+
+	return DefaultMaxReconnectWait
+}
+
+func (subscriber *Subscriber) reportError(err error) {
+	if err != nil && subscriber.OnError != nil {
+		subscriber.OnError(err)
+	}
+}
+
+func pendingTransactionHashes(ctx context.Context, client *Client) ([]string, error) {
+	reply, err := client.RawRequest(ctx, goSdkRPC.Method.GetPendingTxnsInPool, []interface{}{})
+	if err != nil {
+		return nil, err
+	}
+
+	response := struct {
+		Result []struct {
+			Hash string `json:"hash"`
+		} `json:"result"`
+		Error *RPCError `json:"error,omitempty"`
+	}{}
+	if err := json.Unmarshal(reply, &response); err != nil {
+		return nil, err
+	}
+
+	if response.Error != nil {
+		return nil, *response.Error
+	}
+
+	hashes := make([]string, 0, len(response.Result))
+	for _, tx := range response.Result {
+		hashes = append(hashes, tx.Hash)
+	}
+
+	return hashes, nil
+}
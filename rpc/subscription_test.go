@@ -0,0 +1,217 @@
+package rpc_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/harmony-one/go-lib/rpc"
+	"github.com/harmony-one/go-lib/rpc/mock"
+)
+
+const testTimeout = 5 * time.Second
+
+func newTestSubscriber(node *mock.Node, polling bool) *rpc.Subscriber {
+	subscriber := rpc.NewSubscriber(rpc.NewClient(node.URL), node.ShardID)
+	subscriber.URL = node.WSURL
+	subscriber.Polling = polling
+	subscriber.PollInterval = 10 * time.Millisecond
+	subscriber.ReconnectWait = 10 * time.Millisecond
+	subscriber.MaxReconnectWait = 50 * time.Millisecond
+
+	return subscriber
+}
+
+func waitFor(t *testing.T, description string, condition func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(testTimeout)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", description)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func nextHeader(t *testing.T, headers <-chan rpc.Header) rpc.Header {
+	t.Helper()
+
+	select {
+	case header, ok := <-headers:
+		if !ok {
+			t.Fatal("the header channel was closed")
+		}
+		return header
+	case <-time.After(testTimeout):
+		t.Fatal("timed out waiting for a header")
+	}
+
+	return rpc.Header{}
+}
+
+func expectHeaders(t *testing.T, headers <-chan rpc.Header, source string, blockNumbers ...uint64) {
+	t.Helper()
+
+	for _, blockNumber := range blockNumbers {
+		header := nextHeader(t, headers)
+		if header.BlockNumber != blockNumber || header.Source != source {
+			t.Fatalf("expected block %d from %s, got block %d from %s", blockNumber, source, header.BlockNumber, header.Source)
+		}
+	}
+}
+
+func TestSubscribeNewHeads(t *testing.T) {
+	node := mock.NewNode()
+	defer node.Close()
+
+	node.SetEpoch(4)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	headers := newTestSubscriber(node, false).SubscribeNewHeads(ctx)
+
+	waitFor(t, "the newHeads subscription", func() bool { return node.Subscriptions(rpc.NewHeadsSubscription) == 1 })
+
+	node.AddBlock(mock.Block{Number: 1, Hash: "0x01"})
+	node.AddBlock(mock.Block{Number: 2, Hash: "0x02"})
+
+	for _, expected := range []rpc.Header{{BlockNumber: 1, Hash: "0x01"}, {BlockNumber: 2, Hash: "0x02"}} {
+		header := nextHeader(t, headers)
+		if header.BlockNumber != expected.BlockNumber || header.Hash != expected.Hash || header.Epoch != 4 || header.ShardID != 0 || header.Source != rpc.SourceWebSocket {
+			t.Errorf("expected block %d (%s) pushed over the websocket, got %+v", expected.BlockNumber, expected.Hash, header)
+		}
+
+		if header.ReceivedAt.IsZero() {
+			t.Error("expected the receive time to be set")
+		}
+	}
+
+	cancel()
+
+	select {
+	case _, ok := <-headers:
+		if ok {
+			t.Error("expected no more headers after cancelling the subscription")
+		}
+	case <-time.After(testTimeout):
+		t.Error("expected the header channel to be closed after cancelling the subscription")
+	}
+}
+
+func TestSubscribeNewHeadsFillsGapAfterReconnecting(t *testing.T) {
+	node := mock.NewNode()
+	defer node.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	headers := newTestSubscriber(node, false).SubscribeNewHeads(ctx)
+
+	waitFor(t, "the newHeads subscription", func() bool { return node.Subscriptions(rpc.NewHeadsSubscription) == 1 })
+	node.AddBlock(mock.Block{Number: 1})
+	expectHeaders(t, headers, rpc.SourceWebSocket, 1)
+
+	// Drop the connection and keep the node from accepting a new one until the missed blocks have been produced
+	node.SetWebSocketAvailable(false)
+	waitFor(t, "the connection to be dropped", func() bool { return node.Subscriptions("") == 0 })
+
+	for blockNumber := uint64(2); blockNumber <= 4; blockNumber++ {
+		node.AddBlock(mock.Block{Number: blockNumber})
+	}
+
+	node.SetWebSocketAvailable(true)
+	waitFor(t, "the subscriber to reconnect", func() bool { return node.Subscriptions(rpc.NewHeadsSubscription) == 1 })
+
+	node.AddBlock(mock.Block{Number: 5})
+
+	expectHeaders(t, headers, rpc.SourcePolling, 2, 3, 4)
+	expectHeaders(t, headers, rpc.SourceWebSocket, 5)
+}
+
+func TestSubscribeNewHeadsFallsBackToPolling(t *testing.T) {
+	node := mock.NewNode()
+	defer node.Close()
+
+	node.SetWebSocketAvailable(false)
+	node.AddBlock(mock.Block{Number: 1})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errs := make(chan error, 100)
+	subscriber := newTestSubscriber(node, true)
+	subscriber.OnError = func(err error) {
+		select {
+		case errs <- err:
+		default:
+		}
+	}
+
+	headers := subscriber.SubscribeNewHeads(ctx)
+	expectHeaders(t, headers, rpc.SourcePolling, 1)
+
+	node.AddBlock(mock.Block{Number: 2})
+	node.AddBlock(mock.Block{Number: 3})
+	expectHeaders(t, headers, rpc.SourcePolling, 2, 3)
+
+	select {
+	case <-errs:
+	default:
+		t.Error("expected the failed websocket handshakes to be reported")
+	}
+
+	// Once the websocket endpoint is back the subscriber switches back to it
+	node.SetWebSocketAvailable(true)
+	waitFor(t, "the subscriber to reconnect", func() bool { return node.Subscriptions(rpc.NewHeadsSubscription) == 1 })
+
+	node.AddBlock(mock.Block{Number: 4})
+	expectHeaders(t, headers, rpc.SourceWebSocket, 4)
+}
+
+func TestSubscribePendingTransactionsFallsBackToPolling(t *testing.T) {
+	node := mock.NewNode()
+	defer node.Close()
+
+	node.SetWebSocketAvailable(false)
+
+	pool := make(chan []string, 1)
+	pool <- []string{"0x01"}
+	node.Handle("pendingTransactions", func(params []json.RawMessage) (interface{}, *mock.Error) {
+		hashes := <-pool
+		pool <- hashes
+
+		result := []map[string]interface{}{}
+		for _, hash := range hashes {
+			result = append(result, map[string]interface{}{"hash": hash})
+		}
+		return result, nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	transactions := newTestSubscriber(node, true).SubscribePendingTransactions(ctx)
+
+	next := func() rpc.PendingTransaction {
+		select {
+		case tx := <-transactions:
+			return tx
+		case <-time.After(testTimeout):
+			t.Fatal("timed out waiting for a pending transaction")
+		}
+		return rpc.PendingTransaction{}
+	}
+
+	if tx := next(); tx.Hash != "0x01" || tx.Source != rpc.SourcePolling {
+		t.Fatalf("expected 0x01 from polling, got %+v", tx)
+	}
+
+	<-pool
+	pool <- []string{"0x01", "0x02"}
+
+	// 0x01 is still in the pool but has already been delivered
+	if tx := next(); tx.Hash != "0x02" || tx.Source != rpc.SourcePolling {
+		t.Fatalf("expected 0x02 from polling, got %+v", tx)
+	}
+}
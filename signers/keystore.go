@@ -0,0 +1,62 @@
+package signers
+
+import (
+	"math/big"
+
+	libErrors "github.com/harmony-one/go-lib/errors"
+	"github.com/harmony-one/go-sdk/pkg/address"
+	"github.com/harmony-one/harmony/accounts"
+	"github.com/harmony-one/harmony/accounts/keystore"
+	"github.com/harmony-one/harmony/core/types"
+	hmyStaking "github.com/harmony-one/harmony/staking/types"
+)
+
+// KeystoreSigner - signs transactions using an unlocked account in a keystore
+type KeystoreSigner struct {
+	Keystore *keystore.KeyStore
+	Account  *accounts.Account
+}
+
+// NewKeystoreSigner - creates a new keystore signer for a given keystore / account
+func NewKeystoreSigner(keystore *keystore.KeyStore, account *accounts.Account) *KeystoreSigner {
+	return &KeystoreSigner{
+		Keystore: keystore,
+		Account:  account,
+	}
+}
+
+// Address - the bech32 address of the keystore account
+func (signer *KeystoreSigner) Address() string {
+	if signer.Account == nil {
+		return ""
+	}
+
+	return address.ToBech32(signer.Account.Address)
+}
+
+// SignTx - signs a regular transaction using the keystore account
+func (signer *KeystoreSigner) SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	if signer.Keystore == nil || signer.Account == nil {
+		return nil, libErrors.ErrMissingAccount
+	}
+
+	return signer.Keystore.SignTx(*signer.Account, tx, chainID)
+}
+
+// SignEthTx - signs an eth compatible transaction using the keystore account
+func (signer *KeystoreSigner) SignEthTx(tx *types.EthTransaction, chainID *big.Int) (*types.EthTransaction, error) {
+	if signer.Keystore == nil || signer.Account == nil {
+		return nil, libErrors.ErrMissingAccount
+	}
+
+	return signer.Keystore.SignEthTx(*signer.Account, tx, chainID)
+}
+
+// SignStakingTx - signs a staking transaction using the keystore account
+func (signer *KeystoreSigner) SignStakingTx(tx *hmyStaking.StakingTransaction, chainID *big.Int) (*hmyStaking.StakingTransaction, error) {
+	if signer.Keystore == nil || signer.Account == nil {
+		return nil, libErrors.ErrMissingAccount
+	}
+
+	return signer.Keystore.SignStakingTx(*signer.Account, tx, chainID)
+}
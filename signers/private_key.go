@@ -0,0 +1,52 @@
+package signers
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/harmony-one/go-sdk/pkg/address"
+	"github.com/harmony-one/harmony/core/types"
+	hmyStaking "github.com/harmony-one/harmony/staking/types"
+)
+
+// PrivateKeySigner - signs transactions using an in-memory private key
+type PrivateKeySigner struct {
+	key *ecdsa.PrivateKey
+}
+
+// NewPrivateKeySigner - creates a new private key signer using a hex encoded raw private key
+func NewPrivateKeySigner(privateKey string) (*PrivateKeySigner, error) {
+	key, err := crypto.HexToECDSA(strings.TrimPrefix(privateKey, "0x"))
+	if err != nil {
+		return nil, err
+	}
+
+	return NewECDSAKeySigner(key), nil
+}
+
+// NewECDSAKeySigner - creates a new private key signer using an ECDSA private key
+func NewECDSAKeySigner(key *ecdsa.PrivateKey) *PrivateKeySigner {
+	return &PrivateKeySigner{key: key}
+}
+
+// Address - the bech32 address derived from the private key
+func (signer *PrivateKeySigner) Address() string {
+	return address.ToBech32(crypto.PubkeyToAddress(signer.key.PublicKey))
+}
+
+// SignTx - signs a regular transaction using the private key
+func (signer *PrivateKeySigner) SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return types.SignTx(tx, types.NewEIP155Signer(chainID), signer.key)
+}
+
+// SignEthTx - signs an eth compatible transaction using the private key
+func (signer *PrivateKeySigner) SignEthTx(tx *types.EthTransaction, chainID *big.Int) (*types.EthTransaction, error) {
+	return types.SignEthTx(tx, types.NewEIP155Signer(chainID), signer.key)
+}
+
+// SignStakingTx - signs a staking transaction using the private key
+func (signer *PrivateKeySigner) SignStakingTx(tx *hmyStaking.StakingTransaction, chainID *big.Int) (*hmyStaking.StakingTransaction, error) {
+	return hmyStaking.Sign(tx, hmyStaking.NewEIP155Signer(chainID), signer.key)
+}
@@ -0,0 +1,215 @@
+package signers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"time"
+
+	eth_hexutil "github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/harmony-one/go-sdk/pkg/address"
+	"github.com/harmony-one/harmony/core/types"
+	hmyStaking "github.com/harmony-one/harmony/staking/types"
+)
+
+var (
+	// DefaultRemoteSignerTimeout - default timeout for requests to a remote signer
+	DefaultRemoteSignerTimeout = 30 * time.Second
+)
+
+// RemoteSignRequest - the request body POSTed to a remote signer
+type RemoteSignRequest struct {
+	Address        string `json:"address"`
+	Type           string `json:"type"`
+	ChainID        string `json:"chain-id"`
+	RawTransaction string `json:"raw-transaction"` // RawTransaction - RLP hex of the unsigned tx
+}
+
+// RemoteSignResponse - the response body returned by a remote signer
+type RemoteSignResponse struct {
+	RawTransaction string `json:"raw-transaction,omitempty"` // RawTransaction - RLP hex of the signed tx
+	Error          string `json:"error,omitempty"`
+}
+
+// RemoteSigner - signs transactions by sending them to a remote signing service over HTTP
+// The service receives a RemoteSignRequest and has to reply with a RemoteSignResponse containing the signed tx
+// Signed txs are verified to be signed by Address and to contain the same payload as the unsigned tx before they're returned
+type RemoteSigner struct {
+	Endpoint   string
+	Account    string
+	Headers    map[string]string
+	HTTPClient *http.Client
+}
+
+// NewRemoteSigner - creates a new remote signer for a given endpoint and bech32 account address
+func NewRemoteSigner(endpoint string, account string) *RemoteSigner {
+	return &RemoteSigner{
+		Endpoint:   endpoint,
+		Account:    account,
+		Headers:    make(map[string]string),
+		HTTPClient: &http.Client{Timeout: DefaultRemoteSignerTimeout},
+	}
+}
+
+// Address - the bech32 address of the remote account
+func (signer *RemoteSigner) Address() string {
+	return signer.Account
+}
+
+// SignTx - signs a regular transaction using the remote signer
+func (signer *RemoteSigner) SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	signedTx := new(types.Transaction)
+	if err := signer.sign(TypeTransaction, chainID, tx, signedTx); err != nil {
+		return nil, err
+	}
+
+	ethSigner := types.NewEIP155Signer(chainID)
+	if ethSigner.Hash(signedTx) != ethSigner.Hash(tx) {
+		return nil, fmt.Errorf("remote signer returned a transaction that doesn't match the submitted transaction")
+	}
+
+	sender, err := types.Sender(ethSigner, signedTx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := signer.verifySender(sender); err != nil {
+		return nil, err
+	}
+
+	return signedTx, nil
+}
+
+// SignEthTx - signs an eth compatible transaction using the remote signer
+func (signer *RemoteSigner) SignEthTx(tx *types.EthTransaction, chainID *big.Int) (*types.EthTransaction, error) {
+	signedTx := new(types.EthTransaction)
+	if err := signer.sign(TypeEthTransaction, chainID, tx, signedTx); err != nil {
+		return nil, err
+	}
+
+	ethSigner := types.NewEIP155Signer(chainID)
+	if ethSigner.Hash(signedTx) != ethSigner.Hash(tx) {
+		return nil, fmt.Errorf("remote signer returned a transaction that doesn't match the submitted transaction")
+	}
+
+	sender, err := types.Sender(ethSigner, signedTx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := signer.verifySender(sender); err != nil {
+		return nil, err
+	}
+
+	return signedTx, nil
+}
+
+// SignStakingTx - signs a staking transaction using the remote signer
+func (signer *RemoteSigner) SignStakingTx(tx *hmyStaking.StakingTransaction, chainID *big.Int) (*hmyStaking.StakingTransaction, error) {
+	signedTx := new(hmyStaking.StakingTransaction)
+	if err := signer.sign(TypeStaking, chainID, tx, signedTx); err != nil {
+		return nil, err
+	}
+
+	stakingSigner := hmyStaking.NewEIP155Signer(chainID)
+	if stakingSigner.Hash(signedTx) != stakingSigner.Hash(tx) {
+		return nil, fmt.Errorf("remote signer returned a transaction that doesn't match the submitted transaction")
+	}
+
+	sender, err := hmyStaking.Sender(stakingSigner, signedTx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := signer.verifySender(sender); err != nil {
+		return nil, err
+	}
+
+	return signedTx, nil
+}
+
+func (signer *RemoteSigner) sign(txType string, chainID *big.Int, tx interface{}, signedTx interface{}) error {
+	if chainID == nil {
+		return fmt.Errorf("a chain id is required to sign a %s using a remote signer", txType)
+	}
+
+	unsigned, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(RemoteSignRequest{
+		Address:        signer.Account,
+		Type:           txType,
+		ChainID:        chainID.String(),
+		RawTransaction: eth_hexutil.Encode(unsigned),
+	})
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequest(http.MethodPost, signer.Endpoint, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	for key, value := range signer.Headers {
+		request.Header.Set(key, value)
+	}
+
+	httpClient := signer.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	responseBody, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+
+	var signResponse RemoteSignResponse
+	if err := json.Unmarshal(responseBody, &signResponse); err != nil {
+		if response.StatusCode != http.StatusOK {
+			return fmt.Errorf("remote signer returned http status code %d", response.StatusCode)
+		}
+		return err
+	}
+
+	if signResponse.Error != "" {
+		return fmt.Errorf("remote signer returned an error: %s", signResponse.Error)
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("remote signer returned http status code %d", response.StatusCode)
+	}
+
+	signed, err := eth_hexutil.Decode(signResponse.RawTransaction)
+	if err != nil {
+		return err
+	}
+
+	return rlp.DecodeBytes(signed, signedTx)
+}
+
+func (signer *RemoteSigner) verifySender(sender address.T) error {
+	expected, err := address.Bech32ToAddress(signer.Account)
+	if err != nil {
+		return err
+	}
+
+	if sender != expected {
+		return fmt.Errorf("remote signer signed the transaction using %s instead of %s", address.ToBech32(sender), signer.Account)
+	}
+
+	return nil
+}
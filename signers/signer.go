@@ -0,0 +1,27 @@
+package signers
+
+import (
+	"math/big"
+
+	"github.com/harmony-one/harmony/core/types"
+	hmyStaking "github.com/harmony-one/harmony/staking/types"
+)
+
+// Supported transaction types
+const (
+	TypeTransaction    = "transaction"
+	TypeEthTransaction = "eth"
+	TypeStaking        = "staking"
+)
+
+// Signer - signs transactions on behalf of a single account, allows plugging in custom key custody solutions
+type Signer interface {
+	// Address - the bech32 address of the account the signer signs for
+	Address() string
+	// SignTx - signs a regular transaction
+	SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+	// SignEthTx - signs an eth compatible transaction
+	SignEthTx(tx *types.EthTransaction, chainID *big.Int) (*types.EthTransaction, error)
+	// SignStakingTx - signs a staking transaction
+	SignStakingTx(tx *hmyStaking.StakingTransaction, chainID *big.Int) (*hmyStaking.StakingTransaction, error)
+}
@@ -1,15 +1,16 @@
 package staking
 
 import (
+	"context"
 	"encoding/base64"
-	"fmt"
 	"math/big"
-	"time"
 
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/harmony-one/go-lib/crypto"
 	libErrors "github.com/harmony-one/go-lib/errors"
-	"github.com/harmony-one/go-lib/network"
+	"github.com/harmony-one/go-lib/logging"
+	libRPC "github.com/harmony-one/go-lib/rpc"
+	"github.com/harmony-one/go-lib/signers"
 	"github.com/harmony-one/go-lib/transactions"
 	"github.com/harmony-one/go-sdk/pkg/common"
 	"github.com/harmony-one/go-sdk/pkg/rpc"
@@ -24,7 +25,7 @@ import (
 func SendTx(
 	keystore *keystore.KeyStore,
 	account *accounts.Account,
-	rpcClient *rpc.HTTPMessenger,
+	rpcClient rpc.T,
 	chain *common.ChainID,
 	gasLimit int64,
 	gasPrice numeric.Dec,
@@ -34,55 +35,95 @@ func SendTx(
 	timeout int,
 	payloadGenerator hmyStaking.StakeMsgFulfiller,
 	logMessage string,
-) (map[string]interface{}, error) {
+) (*transactions.Receipt, error) {
 	if keystore == nil || account == nil {
 		return nil, libErrors.ErrMissingAccount
 	}
-	stakingTx, calculatedGasLimit, err := GenerateStakingTransaction(gasLimit, gasPrice, nonce, payloadGenerator)
-	if err != nil {
-		return nil, err
-	}
 
-	signedTx, err := SignStakingTransaction(keystore, account, stakingTx, chain.Value)
-	if err != nil {
-		return nil, err
-	}
+	return SendTxWithSigner(signers.NewKeystoreSigner(keystore, account), rpcClient, chain, gasLimit, gasPrice, nonce, node, timeout, payloadGenerator, logMessage)
+}
 
-	signature, err := transactions.EncodeSignature(signedTx)
-	if err != nil {
-		return nil, err
+// SendTxWithSigner - generate the staking tx, sign it using a given signer, encode the signature and send the actual tx data
+func SendTxWithSigner(
+	signer signers.Signer,
+	rpcClient rpc.T,
+	chain *common.ChainID,
+	gasLimit int64,
+	gasPrice numeric.Dec,
+	nonce uint64,
+	node string,
+	timeout int,
+	payloadGenerator hmyStaking.StakeMsgFulfiller,
+	logMessage string,
+) (*transactions.Receipt, error) {
+	if signer == nil {
+		return nil, libErrors.ErrMissingAccount
 	}
 
-	if logMessage != "" {
-		logMessage = fmt.Sprintf("\n[Harmony SDK]: %s - %s\n\tGas Limit: %d\n\tGas Price: %f\n\tNonce: %d\n\tSignature: %v\n",
-			time.Now().Format(network.LoggingTimeFormat),
-			logMessage,
-			calculatedGasLimit,
-			gasPrice,
-			nonce,
-			signature,
-		)
-		fmt.Println(logMessage)
+	txContext := transactions.NewLegacyTxContext(signer, rpcClient, chain, gasLimit, gasPrice, nonce, node, timeout).WithIgnoreWaitErrors(true)
+
+	return SendTxWithTxContext(context.Background(), txContext, payloadGenerator, logMessage)
+}
+
+// SendTxWithTxContext - generate the staking tx, sign it and send it using the signer, client, chain, gas, nonce and wait policy of a given tx context
+// Staking txs are always sent to the beacon shard (shard 0), the payload is validated using Preflight unless the tx context skips pre-flight checks
+func SendTxWithTxContext(ctx context.Context, txContext *transactions.TxContext, payloadGenerator hmyStaking.StakeMsgFulfiller, logMessage string) (*transactions.Receipt, error) {
+	return SendCheckedTxWithTxContext(ctx, txContext, payloadGenerator, logMessage, nil)
+}
+
+// SendCheckedTxWithTxContext - same as SendTxWithTxContext, additionally running a directive specific pre-flight check that requires chain state (e.g. validator.ValidateEdit) if the tx context has a client
+// The check receives the estimated tx fee (in ONE) so that balance checks can include it
+// In dry-run mode failed pre-flight checks don't abort the tx, they're reported in the receipt's DryRunResult instead
+func SendCheckedTxWithTxContext(ctx context.Context, txContext *transactions.TxContext, payloadGenerator hmyStaking.StakeMsgFulfiller, logMessage string, check func(ctx context.Context, client *libRPC.Client, fee numeric.Dec) error) (*transactions.Receipt, error) {
+	preflightErrors := []error{}
+	if !txContext.SkipPreflight {
+		fee, err := EstimateFee(txContext.GasLimit, txContext.GasPrice, payloadGenerator)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := Preflight(ctx, txContext.Client, payloadGenerator, fee); err != nil {
+			preflightErrors = append(preflightErrors, err)
+		}
+
+		if check != nil && txContext.Client != nil {
+			if err := check(ctx, txContext.Client, fee); err != nil {
+				preflightErrors = append(preflightErrors, err)
+			}
+		}
+
+		if len(preflightErrors) > 0 && !txContext.DryRun {
+			return nil, preflightErrors[0]
+		}
 	}
 
-	receiptHash, err := SendRawStakingTransaction(rpcClient, signature)
+	receipt, err := txContext.Send(ctx, signers.TypeStaking, 0, 0, func(nonce uint64) (interface{}, error) {
+		stakingTx, calculatedGasLimit, err := GenerateStakingTransaction(txContext.GasLimit, txContext.GasPrice, nonce, payloadGenerator)
+		if err != nil {
+			return nil, err
+		}
+
+		if logMessage != "" {
+			logging.Resolve(txContext.Logger, logging.FromContext(ctx)).Log(logging.DebugLevel, logMessage,
+				logging.F("gas-limit", calculatedGasLimit),
+				logging.F("gas-price", txContext.GasPrice),
+				logging.Nonce(nonce),
+			)
+		}
+
+		return txContext.Signer.SignStakingTx(stakingTx, txContext.Chain.Value)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	if timeout > 0 {
-		hash := receiptHash.(string)
-		result, _ := transactions.WaitForTxConfirmation(rpcClient, node, "staking", hash, timeout)
-
-		if result != nil {
-			return result, nil
+	if receipt.DryRun != nil {
+		for _, preflightError := range preflightErrors {
+			receipt.DryRun.AddPreflightError(preflightError)
 		}
 	}
 
-	result := make(map[string]interface{})
-	result["transactionHash"] = receiptHash
-
-	return result, nil
+	return receipt, nil
 }
 
 // GenerateStakingTransaction - generate a staking transaction
@@ -110,6 +151,18 @@ func GenerateStakingTransaction(gasLimit int64, gasPrice numeric.Dec, nonce uint
 	return stakingTx, calculatedGasLimit, nil
 }
 
+// EstimateFee - returns the fee (gas limit * gas price, in ONE) of the staking tx generated for a given gas limit, gas price and payload
+func EstimateFee(gasLimit int64, gasPrice numeric.Dec, payloadGenerator hmyStaking.StakeMsgFulfiller) (numeric.Dec, error) {
+	stakingTx, _, err := GenerateStakingTransaction(gasLimit, gasPrice, 0, payloadGenerator)
+	if err != nil {
+		return numeric.ZeroDec(), err
+	}
+
+	fee := new(big.Int).Mul(stakingTx.GasPrice(), new(big.Int).SetUint64(stakingTx.GasLimit()))
+
+	return numeric.NewDecFromBigInt(fee).Quo(transactions.OneAsDec), nil
+}
+
 // ProcessBlsKeys - separate bls keys to pub key and sig slices
 func ProcessBlsKeys(blsKeys []crypto.BLSKey) (blsPubKeys []bls.SerializedPublicKey, blsSigs []bls.SerializedSignature) {
 	blsPubKeys = make([]bls.SerializedPublicKey, len(blsKeys))
@@ -133,16 +186,14 @@ func SignStakingTransaction(keystore *keystore.KeyStore, account *accounts.Accou
 	return signedTransaction, nil
 }
 
-// SendRawStakingTransaction - send the raw staking tx to the RPC endpoint
-func SendRawStakingTransaction(rpcClient *rpc.HTTPMessenger, signature *string) (interface{}, error) {
+// SendRawStakingTransaction - send the raw staking tx to the RPC endpoint and return the transaction hash
+func SendRawStakingTransaction(rpcClient rpc.T, signature *string) (string, error) {
 	reply, err := rpcClient.SendRPC(rpc.Method.SendRawStakingTransaction, []interface{}{signature})
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
-	receiptHash, _ := reply["result"]
-
-	return receiptHash, nil
+	return transactions.ParseTransactionHash(reply)
 }
 
 // NumericDecToBigIntAmount - convert a numeric.Dec amount to a converted big.Int amount
@@ -154,3 +205,90 @@ func NumericDecToBigIntAmount(amount numeric.Dec) (bigAmount *big.Int) {
 
 	return bigAmount
 }
+
+// BigIntAmountToNumericDec - convert a big.Int amount to a numeric.Dec amount, the inverse of NumericDecToBigIntAmount
+func BigIntAmountToNumericDec(bigAmount *big.Int) numeric.Dec {
+	if bigAmount == nil {
+		return numeric.ZeroDec()
+	}
+
+	return numeric.NewDecFromBigInt(bigAmount).Quo(transactions.OneAsDec)
+}
+
+// ReplaceStuckTx - waits for a pending staking tx to be confirmed and re-signs/resubmits it using the same nonce and a bumped gas price every time it isn't confirmed within policy.Timeout
+func ReplaceStuckTx(
+	keystore *keystore.KeyStore,
+	account *accounts.Account,
+	rpcClient rpc.T,
+	chain *common.ChainID,
+	gasLimit int64,
+	gasPrice numeric.Dec,
+	nonce uint64,
+	node string,
+	payloadGenerator hmyStaking.StakeMsgFulfiller,
+	txHash string,
+	policy transactions.ReplacementPolicy,
+) (*transactions.Receipt, error) {
+	if keystore == nil || account == nil {
+		return nil, libErrors.ErrMissingAccount
+	}
+
+	return ReplaceStuckTxWithSigner(signers.NewKeystoreSigner(keystore, account), rpcClient, chain, gasLimit, gasPrice, nonce, node, payloadGenerator, txHash, policy)
+}
+
+// ReplaceStuckTxWithSigner - same as ReplaceStuckTx but re-signs the replacement txs using a given signer
+func ReplaceStuckTxWithSigner(
+	signer signers.Signer,
+	rpcClient rpc.T,
+	chain *common.ChainID,
+	gasLimit int64,
+	gasPrice numeric.Dec,
+	nonce uint64,
+	node string,
+	payloadGenerator hmyStaking.StakeMsgFulfiller,
+	txHash string,
+	policy transactions.ReplacementPolicy,
+) (*transactions.Receipt, error) {
+	if signer == nil {
+		return nil, libErrors.ErrMissingAccount
+	}
+
+	txContext := transactions.NewLegacyTxContext(signer, rpcClient, chain, gasLimit, gasPrice, nonce, node, 0)
+
+	return ReplaceStuckTxWithTxContext(context.Background(), txContext, payloadGenerator, txHash, policy)
+}
+
+// ReplaceStuckTxWithTxContext - same as ReplaceStuckTx using a given tx context, whose nonce has to be the nonce of the stuck tx
+func ReplaceStuckTxWithTxContext(
+	ctx context.Context,
+	txContext *transactions.TxContext,
+	payloadGenerator hmyStaking.StakeMsgFulfiller,
+	txHash string,
+	policy transactions.ReplacementPolicy,
+) (*transactions.Receipt, error) {
+	if err := txContext.Validate(); err != nil {
+		return nil, err
+	}
+
+	if txContext.Client == nil {
+		return nil, transactions.ErrMissingClient
+	}
+
+	resend := func(bumpedGasPrice numeric.Dec) (string, error) {
+		return txContext.Resend(ctx, signers.TypeStaking, func(nonce uint64) (interface{}, error) {
+			stakingTx, _, err := GenerateStakingTransaction(txContext.GasLimit, bumpedGasPrice, nonce, payloadGenerator)
+			if err != nil {
+				return nil, err
+			}
+
+			return txContext.Signer.SignStakingTx(stakingTx, txContext.Chain.Value)
+		})
+	}
+
+	return transactions.ReplaceUntilConfirmed(ctx, txContext.Watcher(signers.TypeStaking), policy, txHash, txContext.GasPrice, BumpStakingGasPrice, resend)
+}
+
+// BumpStakingGasPrice - bumps the gas price like transactions.BumpGasPrice, rounding up since staking txs only use the integer part of the gas price
+func BumpStakingGasPrice(gasPrice numeric.Dec) numeric.Dec {
+	return transactions.BumpGasPrice(gasPrice).Ceil()
+}
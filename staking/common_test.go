@@ -0,0 +1,22 @@
+package staking_test
+
+import (
+	"testing"
+
+	"github.com/harmony-one/go-lib/staking"
+	"github.com/harmony-one/harmony/numeric"
+)
+
+func TestBumpStakingGasPrice(t *testing.T) {
+	// Staking gas prices are integers, the bumped price is rounded up so small prices still increase
+	ladder := []int64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 13, 15}
+
+	gasPrice := numeric.NewDec(ladder[0])
+	for _, expected := range ladder[1:] {
+		bumped := staking.BumpStakingGasPrice(gasPrice)
+		if !bumped.Equal(numeric.NewDec(expected)) {
+			t.Fatalf("expected %s to be bumped to %d, got %s", gasPrice, expected, bumped)
+		}
+		gasPrice = bumped
+	}
+}
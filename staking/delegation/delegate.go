@@ -1,10 +1,14 @@
 package delegation
 
 import (
+	"context"
 	"fmt"
 
-	"github.com/harmony-one/go-lib/network"
+	"github.com/harmony-one/go-lib/logging"
+	libRPC "github.com/harmony-one/go-lib/rpc"
+	"github.com/harmony-one/go-lib/signers"
 	"github.com/harmony-one/go-lib/staking"
+	"github.com/harmony-one/go-lib/transactions"
 	"github.com/harmony-one/go-sdk/pkg/address"
 	"github.com/harmony-one/go-sdk/pkg/common"
 	"github.com/harmony-one/go-sdk/pkg/rpc"
@@ -18,7 +22,7 @@ import (
 func Delegate(
 	keystore *keystore.KeyStore,
 	account *accounts.Account,
-	rpcClient *rpc.HTTPMessenger,
+	rpcClient rpc.T,
 	chain *common.ChainID,
 	delegatorAddress string,
 	validatorAddress string,
@@ -29,14 +33,45 @@ func Delegate(
 	keystorePassphrase string,
 	node string,
 	timeout int,
-) (map[string]interface{}, error) {
+) (*transactions.Receipt, error) {
+	return DelegateWithSigner(signers.NewKeystoreSigner(keystore, account), rpcClient, chain, delegatorAddress, validatorAddress, amount, gasLimit, gasPrice, nonce, node, timeout)
+}
+
+// DelegateWithSigner - delegate to a validator using a given signer
+func DelegateWithSigner(
+	signer signers.Signer,
+	rpcClient rpc.T,
+	chain *common.ChainID,
+	delegatorAddress string,
+	validatorAddress string,
+	amount numeric.Dec,
+	gasLimit int64,
+	gasPrice numeric.Dec,
+	nonce uint64,
+	node string,
+	timeout int,
+) (*transactions.Receipt, error) {
+	txContext := transactions.NewLegacyTxContext(signer, rpcClient, chain, gasLimit, gasPrice, nonce, node, timeout).WithFrom(delegatorAddress).WithIgnoreWaitErrors(true)
+
+	return DelegateWithTxContext(context.Background(), txContext, validatorAddress, amount)
+}
+
+// DelegateWithTxContext - delegate to a validator using a given tx context, the tx context's sender is the delegator
+func DelegateWithTxContext(
+	ctx context.Context,
+	txContext *transactions.TxContext,
+	validatorAddress string,
+	amount numeric.Dec,
+) (*transactions.Receipt, error) {
+	delegatorAddress := txContext.FromAddress()
+
 	payloadGenerator, err := createDelegationTransactionGenerator(delegatorAddress, validatorAddress, amount)
 	if err != nil {
 		return nil, err
 	}
 
 	var logMessage string
-	if network.Verbose {
+	if logging.Resolve(txContext.Logger, logging.FromContext(ctx)).Enabled(logging.DebugLevel) {
 		logMessage = fmt.Sprintf("Generating a new delegation transaction:\n\tDelegator Address: %s\n\tValidator Address: %s\n\tAmount: %f",
 			delegatorAddress,
 			validatorAddress,
@@ -44,7 +79,11 @@ func Delegate(
 		)
 	}
 
-	return staking.SendTx(keystore, account, rpcClient, chain, gasLimit, gasPrice, nonce, keystorePassphrase, node, timeout, payloadGenerator, logMessage)
+	check := func(ctx context.Context, client *libRPC.Client, fee numeric.Dec) error {
+		return ValidateDelegate(ctx, client, delegatorAddress, amount, fee)
+	}
+
+	return staking.SendCheckedTxWithTxContext(ctx, txContext, payloadGenerator, logMessage, check)
 }
 
 func createDelegationTransactionGenerator(delegatorAddress string, validatorAddress string, amount numeric.Dec) (hmyStaking.StakeMsgFulfiller, error) {
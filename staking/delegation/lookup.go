@@ -1,37 +1,49 @@
 package delegation
 
 import (
+	"context"
 	"encoding/json"
 
+	"github.com/harmony-one/go-lib/rpc"
 	goSdkRPC "github.com/harmony-one/go-sdk/pkg/rpc"
 )
 
 // ByValidator - get delegations by validator
 func ByValidator(node string, address string) ([]DelegationInfo, error) {
-	return lookupDelegation(node, goSdkRPC.Method.GetDelegationsByValidator, address)
+	return ByValidatorWithContext(context.Background(), rpc.NewClient(node), address)
+}
+
+// ByValidatorWithContext - get delegations by validator using a given context and client
+func ByValidatorWithContext(ctx context.Context, client *rpc.Client, address string) ([]DelegationInfo, error) {
+	return lookupDelegation(ctx, client, goSdkRPC.Method.GetDelegationsByValidator, address)
 }
 
 // ByDelegator - get delegations by delegator
 func ByDelegator(node string, address string) ([]DelegationInfo, error) {
-	return lookupDelegation(node, goSdkRPC.Method.GetDelegationsByDelegator, address)
+	return ByDelegatorWithContext(context.Background(), rpc.NewClient(node), address)
+}
+
+// ByDelegatorWithContext - get delegations by delegator using a given context and client
+func ByDelegatorWithContext(ctx context.Context, client *rpc.Client, address string) ([]DelegationInfo, error) {
+	return lookupDelegation(ctx, client, goSdkRPC.Method.GetDelegationsByDelegator, address)
 }
 
-func lookupDelegation(node string, rpcMethod string, address string) ([]DelegationInfo, error) {
+func lookupDelegation(ctx context.Context, client *rpc.Client, rpcMethod string, address string) ([]DelegationInfo, error) {
 	response := DelegationInfoWrapper{}
 	delegationInfo := []DelegationInfo{}
 
-	bytes, err := goSdkRPC.RawRequest(rpcMethod, node, []interface{}{address})
+	bytes, err := client.RawRequest(ctx, rpcMethod, []interface{}{address})
 	if err != nil {
 		return delegationInfo, err
 	}
 
-	json.Unmarshal(bytes, &response)
-	delegationInfo = response.Result
-	if len(delegationInfo) > 0 {
-		for _, info := range delegationInfo {
-			info.Initialize()
-		}
+	if err := json.Unmarshal(bytes, &response); err != nil {
+		return delegationInfo, err
+	}
+
+	if response.Error != nil {
+		return delegationInfo, *response.Error
 	}
 
-	return delegationInfo, nil
+	return InitializeDelegationInfos(response.Result)
 }
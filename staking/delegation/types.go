@@ -3,6 +3,7 @@ package delegation
 import (
 	"math/big"
 
+	"github.com/harmony-one/go-lib/rpc"
 	"github.com/harmony-one/harmony/common/denominations"
 	"github.com/harmony-one/harmony/numeric"
 )
@@ -12,6 +13,7 @@ type DelegationInfoWrapper struct {
 	ID      string           `json:"id" yaml:"id"`
 	JSONRPC string           `json:"jsonrpc" yaml:"jsonrpc"`
 	Result  []DelegationInfo `json:"result" yaml:"result"`
+	Error   *rpc.RPCError    `json:"error,omitempty" yaml:"error,omitempty"`
 }
 
 // DelegationInfo - the actual delegation info
@@ -97,3 +99,13 @@ func (undelegationInfo *UndelegationInfo) Initialize() error {
 	}
 	return nil
 }
+
+// UnlockEpoch - returns the epoch at which the undelegated tokens are returned to the delegator's balance given the network's lock period (see utils.LookupLockPeriod)
+func (undelegationInfo *UndelegationInfo) UnlockEpoch(lockPeriod int) int {
+	return undelegationInfo.Epoch + lockPeriod
+}
+
+// IsUnlocked - returns true if the undelegated tokens are unlocked at a given epoch given the network's lock period
+func (undelegationInfo *UndelegationInfo) IsUnlocked(currentEpoch int, lockPeriod int) bool {
+	return currentEpoch >= undelegationInfo.UnlockEpoch(lockPeriod)
+}
@@ -1,10 +1,14 @@
 package delegation
 
 import (
+	"context"
 	"fmt"
 
-	"github.com/harmony-one/go-lib/network"
+	"github.com/harmony-one/go-lib/logging"
+	libRPC "github.com/harmony-one/go-lib/rpc"
+	"github.com/harmony-one/go-lib/signers"
 	"github.com/harmony-one/go-lib/staking"
+	"github.com/harmony-one/go-lib/transactions"
 	"github.com/harmony-one/go-sdk/pkg/address"
 	"github.com/harmony-one/go-sdk/pkg/common"
 	"github.com/harmony-one/go-sdk/pkg/rpc"
@@ -18,7 +22,7 @@ import (
 func Undelegate(
 	keystore *keystore.KeyStore,
 	account *accounts.Account,
-	rpcClient *rpc.HTTPMessenger,
+	rpcClient rpc.T,
 	chain *common.ChainID,
 	delegatorAddress string,
 	validatorAddress string,
@@ -29,14 +33,45 @@ func Undelegate(
 	keystorePassphrase string,
 	node string,
 	timeout int,
-) (map[string]interface{}, error) {
+) (*transactions.Receipt, error) {
+	return UndelegateWithSigner(signers.NewKeystoreSigner(keystore, account), rpcClient, chain, delegatorAddress, validatorAddress, amount, gasLimit, gasPrice, nonce, node, timeout)
+}
+
+// UndelegateWithSigner - cancel a previous delegation using a given signer
+func UndelegateWithSigner(
+	signer signers.Signer,
+	rpcClient rpc.T,
+	chain *common.ChainID,
+	delegatorAddress string,
+	validatorAddress string,
+	amount numeric.Dec,
+	gasLimit int64,
+	gasPrice numeric.Dec,
+	nonce uint64,
+	node string,
+	timeout int,
+) (*transactions.Receipt, error) {
+	txContext := transactions.NewLegacyTxContext(signer, rpcClient, chain, gasLimit, gasPrice, nonce, node, timeout).WithFrom(delegatorAddress).WithIgnoreWaitErrors(true)
+
+	return UndelegateWithTxContext(context.Background(), txContext, validatorAddress, amount)
+}
+
+// UndelegateWithTxContext - cancel a previous delegation using a given tx context, the tx context's sender is the delegator
+func UndelegateWithTxContext(
+	ctx context.Context,
+	txContext *transactions.TxContext,
+	validatorAddress string,
+	amount numeric.Dec,
+) (*transactions.Receipt, error) {
+	delegatorAddress := txContext.FromAddress()
+
 	payloadGenerator, err := createUndelegationTransactionGenerator(delegatorAddress, validatorAddress, amount)
 	if err != nil {
 		return nil, err
 	}
 
 	var logMessage string
-	if network.Verbose {
+	if logging.Resolve(txContext.Logger, logging.FromContext(ctx)).Enabled(logging.DebugLevel) {
 		logMessage = fmt.Sprintf("Generating a new undelegation transaction:\n\tDelegator Address: %s\n\tValidator Address: %s\n\tAmount: %f",
 			delegatorAddress,
 			validatorAddress,
@@ -44,7 +79,11 @@ func Undelegate(
 		)
 	}
 
-	return staking.SendTx(keystore, account, rpcClient, chain, gasLimit, gasPrice, nonce, keystorePassphrase, node, timeout, payloadGenerator, logMessage)
+	check := func(ctx context.Context, client *libRPC.Client, fee numeric.Dec) error {
+		return ValidateUndelegate(ctx, client, delegatorAddress, validatorAddress, amount)
+	}
+
+	return staking.SendCheckedTxWithTxContext(ctx, txContext, payloadGenerator, logMessage, check)
 }
 
 func createUndelegationTransactionGenerator(delegatorAddress string, validatorAddress string, amount numeric.Dec) (hmyStaking.StakeMsgFulfiller, error) {
@@ -0,0 +1,218 @@
+package delegation
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/harmony-one/go-lib/network/rpc/block"
+	"github.com/harmony-one/go-lib/network/utils"
+	"github.com/harmony-one/go-lib/rpc"
+	"github.com/harmony-one/go-sdk/pkg/address"
+	"github.com/harmony-one/harmony/numeric"
+)
+
+var (
+	// DefaultBlockTime - block time used for the unlock time estimates if it can't be measured
+	DefaultBlockTime = 2 * time.Second
+
+	// DefaultBlockTimeSampleSize - number of recent blocks used to measure the average block time
+	DefaultBlockTimeSampleSize uint64 = 100
+
+	// DefaultUnlockPollInterval - interval used by WaitForRelease to check if an undelegation has been released
+	DefaultUnlockPollInterval = 30 * time.Second
+
+	// ErrUndelegationNotFound is returned if a delegator has no pending undelegation from a validator for a given epoch
+	ErrUndelegationNotFound = errors.New("no pending undelegation found for the given validator and epoch")
+)
+
+// UnlockSchedule - the pending undelegations of a delegator and when they'll be released to the delegator's balance
+type UnlockSchedule struct {
+	DelegatorAddress string                  `json:"delegatorAddress" yaml:"delegatorAddress"`
+	Epoch            int                     `json:"epoch" yaml:"epoch"`
+	LockPeriod       int                     `json:"lockPeriod" yaml:"lockPeriod"` // LockPeriod - the network's lock period (in epochs) used to calculate the unlock epochs
+	BlockNumber      uint64                  `json:"blockNumber" yaml:"blockNumber"`
+	BlockTime        time.Duration           `json:"blockTime" yaml:"blockTime"` // BlockTime - the average block time used for the estimates
+	Undelegations    []ScheduledUndelegation `json:"undelegations" yaml:"undelegations"`
+}
+
+// ScheduledUndelegation - a pending undelegation and its estimated release
+// Undelegated tokens are released in the last block of their unlock epoch
+type ScheduledUndelegation struct {
+	ValidatorAddress    string      `json:"validatorAddress" yaml:"validatorAddress"`
+	Amount              numeric.Dec `json:"amount" yaml:"amount"`
+	Epoch               int         `json:"epoch" yaml:"epoch"`
+	UnlockEpoch         int         `json:"unlockEpoch" yaml:"unlockEpoch"`
+	RemainingEpochs     int         `json:"remainingEpochs" yaml:"remainingEpochs"` // RemainingEpochs - 0 means the tokens are released at the end of the current epoch
+	UnlockBlock         uint64      `json:"unlockBlock" yaml:"unlockBlock"`
+	RemainingBlocks     uint64      `json:"remainingBlocks" yaml:"remainingBlocks"`
+	EstimatedUnlockTime time.Time   `json:"estimatedUnlockTime" yaml:"estimatedUnlockTime"`
+}
+
+// GetUnlockSchedule - returns the unlock schedule for a given delegator on a given network
+func GetUnlockSchedule(network string, node string, delegatorAddress string) (*UnlockSchedule, error) {
+	lockPeriod, err := utils.LookupLockPeriod(network)
+	if err != nil {
+		return nil, err
+	}
+
+	return GetUnlockScheduleWithContext(context.Background(), rpc.NewClient(node), delegatorAddress, lockPeriod)
+}
+
+// GetUnlockScheduleWithContext - returns the unlock schedule for a given delegator using a given context, client (connected to the beacon shard) and the network's lock period
+// The lock period differs per network, see utils.LookupLockPeriod / Network.LockPeriodInEpochs
+// The unlock times are estimated using the average time of the last DefaultBlockTimeSampleSize blocks
+func GetUnlockScheduleWithContext(ctx context.Context, client *rpc.Client, delegatorAddress string, lockPeriod int) (*UnlockSchedule, error) {
+	delegations, err := ByDelegatorWithContext(ctx, client, delegatorAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	epoch, err := block.GetCurrentEpochWithContext(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	blockNumber, err := rpc.GetCurrentBlockNumberWithContext(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule := &UnlockSchedule{
+		DelegatorAddress: delegatorAddress,
+		Epoch:            int(epoch),
+		LockPeriod:       lockPeriod,
+		BlockNumber:      blockNumber,
+		BlockTime:        measureBlockTime(ctx, client, blockNumber),
+		Undelegations:    []ScheduledUndelegation{},
+	}
+
+	now := time.Now()
+	unlockBlocks := make(map[int]uint64)
+
+	for _, delegation := range delegations {
+		for _, undelegation := range delegation.Undelegations {
+			unlockEpoch := undelegation.UnlockEpoch(lockPeriod)
+			if unlockEpoch < schedule.Epoch {
+				// Still pending past its unlock epoch, the tokens are paid out in the last block of the current epoch
+				unlockEpoch = schedule.Epoch
+			}
+
+			unlockBlock, ok := unlockBlocks[unlockEpoch]
+			if !ok {
+				unlockBlock, err = block.GetEpochLastBlockWithContext(ctx, client, uint64(unlockEpoch))
+				if err != nil {
+					return nil, err
+				}
+				unlockBlocks[unlockEpoch] = unlockBlock
+			}
+
+			scheduled := ScheduledUndelegation{
+				ValidatorAddress: delegation.ValidatorAddress,
+				Amount:           undelegation.Amount,
+				Epoch:            undelegation.Epoch,
+				UnlockEpoch:      unlockEpoch,
+				RemainingEpochs:  unlockEpoch - schedule.Epoch,
+				UnlockBlock:      unlockBlock,
+			}
+
+			if unlockBlock > blockNumber {
+				scheduled.RemainingBlocks = unlockBlock - blockNumber
+			}
+			scheduled.EstimatedUnlockTime = now.Add(time.Duration(scheduled.RemainingBlocks) * schedule.BlockTime)
+
+			schedule.Undelegations = append(schedule.Undelegations, scheduled)
+		}
+	}
+
+	return schedule, nil
+}
+
+// WaitForRelease - blocks until the undelegation made by a delegator from a validator in a given epoch has been released to the delegator's balance
+// pollInterval defaults to DefaultUnlockPollInterval, returns ErrUndelegationNotFound if there's no such pending undelegation and the context's error once it's done
+// Transport errors are retried on the next tick, any other error (e.g. an RPC error) is returned
+func WaitForRelease(ctx context.Context, client *rpc.Client, delegatorAddress string, validatorAddress string, epoch int, pollInterval time.Duration) error {
+	if pollInterval <= 0 {
+		pollInterval = DefaultUnlockPollInterval
+	}
+
+	pending, err := isPending(ctx, client, delegatorAddress, validatorAddress, epoch)
+	if err != nil {
+		return err
+	}
+
+	if !pending {
+		return ErrUndelegationNotFound
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		pending, err := isPending(ctx, client, delegatorAddress, validatorAddress, epoch)
+		if err != nil {
+			if ctx.Err() == nil && rpc.IsRetryable(err) {
+				// The context bounds the total wait
+				continue
+			}
+			return err
+		}
+
+		if !pending {
+			return nil
+		}
+	}
+}
+
+func isPending(ctx context.Context, client *rpc.Client, delegatorAddress string, validatorAddress string, epoch int) (bool, error) {
+	delegations, err := ByDelegatorWithContext(ctx, client, delegatorAddress)
+	if err != nil {
+		return false, err
+	}
+
+	validator := address.Parse(validatorAddress)
+	for _, delegation := range delegations {
+		if address.Parse(delegation.ValidatorAddress) != validator {
+			continue
+		}
+
+		for _, undelegation := range delegation.Undelegations {
+			if undelegation.Epoch == epoch {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// measureBlockTime returns the average block time of the blocks up to blockNumber, or DefaultBlockTime if it can't be measured
+func measureBlockTime(ctx context.Context, client *rpc.Client, blockNumber uint64) time.Duration {
+	sampleSize := DefaultBlockTimeSampleSize
+	if sampleSize == 0 || blockNumber < sampleSize {
+		return DefaultBlockTime
+	}
+
+	latest, err := rpc.GetBlockWithContext(ctx, client, blockNumber, rpc.BlockOptions{})
+	if err != nil {
+		return DefaultBlockTime
+	}
+
+	earlier, err := rpc.GetBlockWithContext(ctx, client, blockNumber-sampleSize, rpc.BlockOptions{})
+	if err != nil {
+		return DefaultBlockTime
+	}
+
+	blockTime := latest.Timestamp.Sub(earlier.Timestamp) / time.Duration(sampleSize)
+	if blockTime <= 0 {
+		return DefaultBlockTime
+	}
+
+	return blockTime
+}
@@ -0,0 +1,99 @@
+package delegation
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+
+	"github.com/harmony-one/go-lib/network/rpc/block"
+	"github.com/harmony-one/go-lib/rpc"
+	"github.com/harmony-one/go-lib/staking"
+	"github.com/harmony-one/go-sdk/pkg/address"
+	goSdkRPC "github.com/harmony-one/go-sdk/pkg/rpc"
+	"github.com/harmony-one/harmony/numeric"
+	hmyStaking "github.com/harmony-one/harmony/staking/types"
+)
+
+// ValidateDelegate - checks that the delegator's balance plus its redelegatable tokens cover the amount and that the balance covers the tx fee (in ONE)
+// Undelegated tokens can only be redelegated once redelegation is enabled on the network and only if they were undelegated before the current epoch
+// The network minimum is checked by staking.ValidatePayload
+func ValidateDelegate(ctx context.Context, client *rpc.Client, delegatorAddress string, amount numeric.Dec, fee numeric.Dec) error {
+	delegations, err := ByDelegatorWithContext(ctx, client, delegatorAddress)
+	if err != nil {
+		return err
+	}
+
+	epoch, err := block.GetCurrentEpochWithContext(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	redelegation, err := isRedelegationEnabled(ctx, client, epoch)
+	if err != nil {
+		return err
+	}
+
+	undelegated := numeric.ZeroDec()
+	if redelegation {
+		for _, delegation := range delegations {
+			for _, undelegation := range delegation.Undelegations {
+				if undelegation.Epoch < int(epoch) {
+					undelegated = undelegated.Add(staking.BigIntAmountToNumericDec(undelegation.RawAmount))
+				}
+			}
+		}
+	}
+
+	return staking.ValidateFunds(ctx, client, hmyStaking.DirectiveDelegate, delegatorAddress, amount, undelegated, fee)
+}
+
+// ValidateUndelegate - checks that the delegator has an existing delegation to the validator that covers the amount
+func ValidateUndelegate(ctx context.Context, client *rpc.Client, delegatorAddress string, validatorAddress string, amount numeric.Dec) error {
+	delegations, err := ByDelegatorWithContext(ctx, client, delegatorAddress)
+	if err != nil {
+		return err
+	}
+
+	validator := address.Parse(validatorAddress)
+	for _, delegation := range delegations {
+		if address.Parse(delegation.ValidatorAddress) != validator {
+			continue
+		}
+
+		if delegated := staking.BigIntAmountToNumericDec(delegation.RawAmount); delegated.LT(amount) {
+			return staking.NewValidationError(hmyStaking.DirectiveUndelegate, "amount", staking.ErrUndelegationTooLarge, "amount: %s, delegated: %s", amount, delegated)
+		}
+
+		return nil
+	}
+
+	return staking.NewValidationError(hmyStaking.DirectiveUndelegate, "validator-address", staking.ErrDelegationNotFound, "delegator: %s, validator: %s", delegatorAddress, validatorAddress)
+}
+
+// isRedelegationEnabled checks the node's chain config to determine if undelegated tokens can be redelegated at a given epoch
+func isRedelegationEnabled(ctx context.Context, client *rpc.Client, epoch uint32) (bool, error) {
+	response := struct {
+		Result struct {
+			ChainConfig struct {
+				RedelegationEpoch *big.Int `json:"redelegation-epoch"`
+			} `json:"chain-config"`
+		} `json:"result"`
+		Error *rpc.RPCError `json:"error,omitempty"`
+	}{}
+
+	bytes, err := client.RawRequest(ctx, goSdkRPC.Method.GetNodeMetadata, []interface{}{})
+	if err != nil {
+		return false, err
+	}
+
+	if err := json.Unmarshal(bytes, &response); err != nil {
+		return false, err
+	}
+
+	if response.Error != nil {
+		return false, *response.Error
+	}
+
+	redelegationEpoch := response.Result.ChainConfig.RedelegationEpoch
+	return redelegationEpoch != nil && redelegationEpoch.Cmp(new(big.Int).SetUint64(uint64(epoch))) <= 0, nil
+}
@@ -0,0 +1,138 @@
+package portfolio
+
+import (
+	"context"
+
+	"github.com/harmony-one/go-lib/network/rpc/block"
+	"github.com/harmony-one/go-lib/network/utils"
+	"github.com/harmony-one/go-lib/rpc"
+	"github.com/harmony-one/go-lib/staking/delegation"
+	"github.com/harmony-one/go-lib/staking/validator"
+	"github.com/harmony-one/harmony/numeric"
+	"github.com/pkg/errors"
+)
+
+// Portfolio - all delegations of a delegator joined with the details of the validators they were made to
+type Portfolio struct {
+	DelegatorAddress       string         `json:"delegatorAddress" yaml:"delegatorAddress"`
+	Epoch                  int            `json:"epoch" yaml:"epoch"` // Epoch - the current epoch, used to determine which undelegations are unlocked
+	Delegations            []Delegation   `json:"delegations" yaml:"delegations"`
+	PendingUndelegations   []Undelegation `json:"pendingUndelegations" yaml:"pendingUndelegations"`
+	TotalStaked            numeric.Dec    `json:"totalStaked" yaml:"totalStaked"`
+	TotalUndelegating      numeric.Dec    `json:"totalUndelegating" yaml:"totalUndelegating"`
+	TotalRewards           numeric.Dec    `json:"totalRewards" yaml:"totalRewards"` // TotalRewards - unclaimed rewards across all delegations
+	EstimatedYearlyRewards numeric.Dec    `json:"estimatedYearlyRewards" yaml:"estimatedYearlyRewards"`
+}
+
+// Delegation - a single delegation joined with the details of its validator
+type Delegation struct {
+	ValidatorAddress       string      `json:"validatorAddress" yaml:"validatorAddress"`
+	ValidatorName          string      `json:"validatorName" yaml:"validatorName"`
+	CommissionRate         numeric.Dec `json:"commissionRate" yaml:"commissionRate"`
+	EposStatus             string      `json:"eposStatus" yaml:"eposStatus"`
+	APR                    numeric.Dec `json:"apr" yaml:"apr"`
+	Amount                 numeric.Dec `json:"amount" yaml:"amount"`
+	Reward                 numeric.Dec `json:"reward" yaml:"reward"` // Reward - unclaimed rewards
+	EstimatedYearlyRewards numeric.Dec `json:"estimatedYearlyRewards" yaml:"estimatedYearlyRewards"`
+}
+
+// Undelegation - undelegated tokens that haven't been returned to the delegator's balance yet
+type Undelegation struct {
+	ValidatorAddress string      `json:"validatorAddress" yaml:"validatorAddress"`
+	Amount           numeric.Dec `json:"amount" yaml:"amount"`
+	Epoch            int         `json:"epoch" yaml:"epoch"`             // Epoch - the epoch the tokens were undelegated in
+	UnlockEpoch      int         `json:"unlockEpoch" yaml:"unlockEpoch"` // UnlockEpoch - the epoch at which the tokens are returned to the delegator's balance
+	Unlocked         bool        `json:"unlocked" yaml:"unlocked"`       // Unlocked - the lock period has passed, the tokens will be returned at the end of the current epoch
+}
+
+// ForDelegator - builds the portfolio for a given delegator address on a given network
+func ForDelegator(network string, node string, delegatorAddress string) (*Portfolio, error) {
+	lockPeriod, err := utils.LookupLockPeriod(network)
+	if err != nil {
+		return nil, err
+	}
+
+	return ForDelegatorWithContext(context.Background(), rpc.NewClient(node), delegatorAddress, lockPeriod)
+}
+
+// ForDelegatorWithContext - builds the portfolio for a given delegator address using a given context, client (connected to the beacon shard) and the network's lock period
+// Estimated yearly rewards are based on the validators' lifetime APR, less their commission
+func ForDelegatorWithContext(ctx context.Context, client *rpc.Client, delegatorAddress string, lockPeriod int) (*Portfolio, error) {
+	delegations, err := delegation.ByDelegatorWithContext(ctx, client, delegatorAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	epoch, err := block.GetCurrentEpochWithContext(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	portfolio := &Portfolio{
+		DelegatorAddress:       delegatorAddress,
+		Epoch:                  int(epoch),
+		Delegations:            []Delegation{},
+		PendingUndelegations:   []Undelegation{},
+		TotalStaked:            numeric.ZeroDec(),
+		TotalUndelegating:      numeric.ZeroDec(),
+		TotalRewards:           numeric.ZeroDec(),
+		EstimatedYearlyRewards: numeric.ZeroDec(),
+	}
+
+	validators := make(map[string]validator.RPCValidatorResult)
+
+	for _, info := range delegations {
+		validatorInfo, ok := validators[info.ValidatorAddress]
+		if !ok {
+			validatorInfo, err = validator.InformationWithContext(ctx, client, info.ValidatorAddress)
+			if err != nil {
+				return nil, errors.Wrapf(err, "validator.Information: %s", info.ValidatorAddress)
+			}
+			validators[info.ValidatorAddress] = validatorInfo
+		}
+
+		entry := newDelegation(info, validatorInfo)
+		portfolio.Delegations = append(portfolio.Delegations, entry)
+		portfolio.TotalStaked = portfolio.TotalStaked.Add(entry.Amount)
+		portfolio.TotalRewards = portfolio.TotalRewards.Add(entry.Reward)
+		portfolio.EstimatedYearlyRewards = portfolio.EstimatedYearlyRewards.Add(entry.EstimatedYearlyRewards)
+
+		for _, undelegationInfo := range info.Undelegations {
+			undelegation := Undelegation{
+				ValidatorAddress: info.ValidatorAddress,
+				Amount:           orZero(undelegationInfo.Amount),
+				Epoch:            undelegationInfo.Epoch,
+				UnlockEpoch:      undelegationInfo.UnlockEpoch(lockPeriod),
+				Unlocked:         undelegationInfo.IsUnlocked(portfolio.Epoch, lockPeriod),
+			}
+			portfolio.PendingUndelegations = append(portfolio.PendingUndelegations, undelegation)
+			portfolio.TotalUndelegating = portfolio.TotalUndelegating.Add(undelegation.Amount)
+		}
+	}
+
+	return portfolio, nil
+}
+
+func newDelegation(info delegation.DelegationInfo, validatorInfo validator.RPCValidatorResult) Delegation {
+	entry := Delegation{
+		ValidatorAddress: info.ValidatorAddress,
+		ValidatorName:    validatorInfo.Validator.Name,
+		CommissionRate:   orZero(validatorInfo.Validator.Rate),
+		EposStatus:       validatorInfo.EposStatus,
+		APR:              orZero(validatorInfo.Lifetime.APR),
+		Amount:           orZero(info.Amount),
+		Reward:           orZero(info.Reward),
+	}
+
+	entry.EstimatedYearlyRewards = entry.Amount.Mul(entry.APR).Mul(numeric.OneDec().Sub(entry.CommissionRate))
+
+	return entry
+}
+
+func orZero(value numeric.Dec) numeric.Dec {
+	if value.IsNil() {
+		return numeric.ZeroDec()
+	}
+
+	return value
+}
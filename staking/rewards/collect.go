@@ -1,10 +1,13 @@
 package rewards
 
 import (
+	"context"
 	"fmt"
 
-	"github.com/harmony-one/go-lib/network"
+	"github.com/harmony-one/go-lib/logging"
+	"github.com/harmony-one/go-lib/signers"
 	"github.com/harmony-one/go-lib/staking"
+	"github.com/harmony-one/go-lib/transactions"
 	"github.com/harmony-one/go-sdk/pkg/address"
 	"github.com/harmony-one/go-sdk/pkg/common"
 	"github.com/harmony-one/go-sdk/pkg/rpc"
@@ -18,7 +21,7 @@ import (
 func CollectRewards(
 	keystore *keystore.KeyStore,
 	account *accounts.Account,
-	rpcClient *rpc.HTTPMessenger,
+	rpcClient rpc.T,
 	chain *common.ChainID,
 	delegatorAddress string,
 	gasLimit int64,
@@ -27,20 +30,44 @@ func CollectRewards(
 	keystorePassphrase string,
 	node string,
 	timeout int,
-) (map[string]interface{}, error) {
+) (*transactions.Receipt, error) {
+	return CollectRewardsWithSigner(signers.NewKeystoreSigner(keystore, account), rpcClient, chain, delegatorAddress, gasLimit, gasPrice, nonce, node, timeout)
+}
+
+// CollectRewardsWithSigner - collects rewards for a given delegator using a given signer
+func CollectRewardsWithSigner(
+	signer signers.Signer,
+	rpcClient rpc.T,
+	chain *common.ChainID,
+	delegatorAddress string,
+	gasLimit int64,
+	gasPrice numeric.Dec,
+	nonce uint64,
+	node string,
+	timeout int,
+) (*transactions.Receipt, error) {
+	txContext := transactions.NewLegacyTxContext(signer, rpcClient, chain, gasLimit, gasPrice, nonce, node, timeout).WithFrom(delegatorAddress).WithIgnoreWaitErrors(true)
+
+	return CollectRewardsWithTxContext(context.Background(), txContext)
+}
+
+// CollectRewardsWithTxContext - collects rewards using a given tx context, the tx context's sender is the delegator
+func CollectRewardsWithTxContext(ctx context.Context, txContext *transactions.TxContext) (*transactions.Receipt, error) {
+	delegatorAddress := txContext.FromAddress()
+
 	payloadGenerator, err := createCollectRewardsTransactionGenerator(delegatorAddress)
 	if err != nil {
 		return nil, err
 	}
 
 	var logMessage string
-	if network.Verbose {
+	if logging.Resolve(txContext.Logger, logging.FromContext(ctx)).Enabled(logging.DebugLevel) {
 		logMessage = fmt.Sprintf("Generating a new collect rewards transaction:\n\tDelegator Address: %s",
 			delegatorAddress,
 		)
 	}
 
-	return staking.SendTx(keystore, account, rpcClient, chain, gasLimit, gasPrice, nonce, keystorePassphrase, node, timeout, payloadGenerator, logMessage)
+	return staking.SendTxWithTxContext(ctx, txContext, payloadGenerator, logMessage)
 }
 
 func createCollectRewardsTransactionGenerator(delegatorAddress string) (hmyStaking.StakeMsgFulfiller, error) {
@@ -0,0 +1,294 @@
+package staking
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harmony-one/go-lib/network/rpc/balances"
+	"github.com/harmony-one/go-lib/rpc"
+	"github.com/harmony-one/go-sdk/pkg/address"
+	"github.com/harmony-one/harmony/crypto/bls"
+	"github.com/harmony-one/harmony/numeric"
+	hmyStaking "github.com/harmony-one/harmony/staking/types"
+)
+
+var (
+	// MinimumSelfDelegation - the network minimum for a validator's min self delegation (in ONE)
+	MinimumSelfDelegation = numeric.NewDec(hmyStaking.TenThousand)
+
+	// MinimumDelegation - the network minimum for a single delegation (in ONE)
+	MinimumDelegation = numeric.NewDec(1000)
+
+	// ErrInvalidCommissionRate is returned if a commission rate, max rate or max change rate isn't within 0.0 and 1.0
+	ErrInvalidCommissionRate = errors.New("commission rate, max rate and max change rate have to be between 0.0 and 1.0")
+
+	// ErrCommissionRateTooLarge is returned if the commission rate or max change rate exceeds the max rate
+	ErrCommissionRateTooLarge = errors.New("commission rate and max change rate can't exceed the max rate")
+
+	// ErrCommissionRateChangeTooLarge is returned if an edit changes the commission rate by more than the validator's max change rate
+	ErrCommissionRateChangeTooLarge = errors.New("commission rate change exceeds the max change rate")
+
+	// ErrMinSelfDelegationTooSmall is returned if the min self delegation is below MinimumSelfDelegation
+	ErrMinSelfDelegationTooSmall = errors.New("min self delegation is below the network minimum")
+
+	// ErrMaxTotalDelegationTooSmall is returned if the max total delegation is below the min self delegation
+	ErrMaxTotalDelegationTooSmall = errors.New("max total delegation can't be less than the min self delegation")
+
+	// ErrAmountTooSmall is returned if a self delegation is below the min self delegation or a delegation is below MinimumDelegation
+	ErrAmountTooSmall = errors.New("amount is below the required minimum")
+
+	// ErrDescriptionTooLong is returned if a description field exceeds its length limit
+	ErrDescriptionTooLong = errors.New("description field exceeds the maximum length")
+
+	// ErrMissingBLSKeys is returned if a validator is created without any BLS keys
+	ErrMissingBLSKeys = errors.New("at least one bls key is required")
+
+	// ErrInvalidBLSKey is returned if a BLS key signature can't be verified
+	ErrInvalidBLSKey = errors.New("bls key signature can't be verified")
+
+	// ErrInsufficientBalance is returned if the sender's balance doesn't cover the amount
+	ErrInsufficientBalance = errors.New("insufficient balance")
+
+	// ErrDelegationNotFound is returned if an undelegation doesn't match an existing delegation
+	ErrDelegationNotFound = errors.New("no existing delegation to the validator")
+
+	// ErrUndelegationTooLarge is returned if an undelegation exceeds the delegated amount
+	ErrUndelegationTooLarge = errors.New("amount exceeds the delegated amount")
+)
+
+// ValidationError - a pre-flight validation failure for a staking directive, use errors.Is to check the cause
+type ValidationError struct {
+	Directive hmyStaking.Directive
+	Field     string
+	Err       error
+	Detail    string
+}
+
+// NewValidationError - creates a new validation error for a given directive and field
+func NewValidationError(directive hmyStaking.Directive, field string, err error, format string, args ...interface{}) *ValidationError {
+	return &ValidationError{Directive: directive, Field: field, Err: err, Detail: fmt.Sprintf(format, args...)}
+}
+
+func (validationError *ValidationError) Error() string {
+	message := fmt.Sprintf("%s: %s: %s", validationError.Directive, validationError.Field, validationError.Err)
+	if validationError.Detail != "" {
+		message = fmt.Sprintf("%s (%s)", message, validationError.Detail)
+	}
+
+	return message
+}
+
+// Unwrap - returns the underlying typed error
+func (validationError *ValidationError) Unwrap() error {
+	return validationError.Err
+}
+
+// Preflight - validates the payload of a given generator before it's signed, checking that the validator's balance covers the amount and the tx fee (in ONE) for create validator directives if client is set
+func Preflight(ctx context.Context, client *rpc.Client, payloadGenerator hmyStaking.StakeMsgFulfiller, fee numeric.Dec) error {
+	if err := ValidatePayload(payloadGenerator); err != nil {
+		return err
+	}
+
+	if client == nil {
+		return nil
+	}
+
+	directive, payload := payloadGenerator()
+
+	if msg, ok := payload.(hmyStaking.CreateValidator); ok {
+		return ValidateBalance(ctx, client, directive, address.ToBech32(msg.ValidatorAddress), BigIntAmountToNumericDec(msg.Amount), fee)
+	}
+
+	return nil
+}
+
+// ValidatePayload - runs the client-side checks that don't require any chain state on the payload of a given generator
+func ValidatePayload(payloadGenerator hmyStaking.StakeMsgFulfiller) error {
+	directive, payload := payloadGenerator()
+
+	switch msg := payload.(type) {
+	case hmyStaking.CreateValidator:
+		return ValidateCreateValidator(msg)
+	case hmyStaking.EditValidator:
+		return ValidateEditValidator(msg)
+	case hmyStaking.Delegate:
+		if BigIntAmountToNumericDec(msg.Amount).LT(MinimumDelegation) {
+			return NewValidationError(directive, "amount", ErrAmountTooSmall, "amount: %s, minimum: %s", BigIntAmountToNumericDec(msg.Amount), MinimumDelegation)
+		}
+	}
+
+	return nil
+}
+
+// ValidateCreateValidator - checks the commission rates, delegation limits, amount, description and BLS keys of a create validator payload
+func ValidateCreateValidator(msg hmyStaking.CreateValidator) error {
+	directive := hmyStaking.DirectiveCreateValidator
+
+	if err := ValidateDescription(directive, msg.Description); err != nil {
+		return err
+	}
+
+	if err := ValidateCommissionRates(directive, msg.CommissionRates); err != nil {
+		return err
+	}
+
+	minSelfDelegation := BigIntAmountToNumericDec(msg.MinSelfDelegation)
+	if minSelfDelegation.LT(MinimumSelfDelegation) {
+		return NewValidationError(directive, "min-self-delegation", ErrMinSelfDelegationTooSmall, "min self delegation: %s, minimum: %s", minSelfDelegation, MinimumSelfDelegation)
+	}
+
+	if maxTotalDelegation := BigIntAmountToNumericDec(msg.MaxTotalDelegation); maxTotalDelegation.LT(minSelfDelegation) {
+		return NewValidationError(directive, "max-total-delegation", ErrMaxTotalDelegationTooSmall, "max total delegation: %s, min self delegation: %s", maxTotalDelegation, minSelfDelegation)
+	}
+
+	if amount := BigIntAmountToNumericDec(msg.Amount); amount.LT(minSelfDelegation) {
+		return NewValidationError(directive, "amount", ErrAmountTooSmall, "amount: %s, min self delegation: %s", amount, minSelfDelegation)
+	}
+
+	if len(msg.SlotPubKeys) == 0 {
+		return NewValidationError(directive, "bls-keys", ErrMissingBLSKeys, "")
+	}
+
+	if len(msg.SlotPubKeys) != len(msg.SlotKeySigs) {
+		return NewValidationError(directive, "bls-keys", ErrInvalidBLSKey, "%d keys, %d signatures", len(msg.SlotPubKeys), len(msg.SlotKeySigs))
+	}
+
+	for index := range msg.SlotPubKeys {
+		if err := ValidateBLSKey(directive, &msg.SlotPubKeys[index], &msg.SlotKeySigs[index]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ValidateEditValidator - checks the description, commission rate, delegation limits and BLS key of an edit validator payload
+// Checks against the current on-chain validator details are done by validator.ValidateEdit
+func ValidateEditValidator(msg hmyStaking.EditValidator) error {
+	directive := hmyStaking.DirectiveEditValidator
+
+	if err := ValidateDescription(directive, msg.Description); err != nil {
+		return err
+	}
+
+	if msg.CommissionRate != nil && !isPercentage(*msg.CommissionRate) {
+		return NewValidationError(directive, "commission-rate", ErrInvalidCommissionRate, "rate: %s", *msg.CommissionRate)
+	}
+
+	if msg.MinSelfDelegation != nil && msg.MinSelfDelegation.Sign() != 0 {
+		minSelfDelegation := BigIntAmountToNumericDec(msg.MinSelfDelegation)
+		if minSelfDelegation.LT(MinimumSelfDelegation) {
+			return NewValidationError(directive, "min-self-delegation", ErrMinSelfDelegationTooSmall, "min self delegation: %s, minimum: %s", minSelfDelegation, MinimumSelfDelegation)
+		}
+
+		if msg.MaxTotalDelegation != nil && msg.MaxTotalDelegation.Sign() != 0 {
+			if maxTotalDelegation := BigIntAmountToNumericDec(msg.MaxTotalDelegation); maxTotalDelegation.LT(minSelfDelegation) {
+				return NewValidationError(directive, "max-total-delegation", ErrMaxTotalDelegationTooSmall, "max total delegation: %s, min self delegation: %s", maxTotalDelegation, minSelfDelegation)
+			}
+		}
+	}
+
+	if msg.SlotKeyToAdd != nil {
+		if msg.SlotKeyToAddSig == nil {
+			return NewValidationError(directive, "bls-key-to-add", ErrInvalidBLSKey, "missing signature")
+		}
+
+		if err := ValidateBLSKey(directive, msg.SlotKeyToAdd, msg.SlotKeyToAddSig); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ValidateDescription - checks the description fields against the network length limits
+func ValidateDescription(directive hmyStaking.Directive, description hmyStaking.Description) error {
+	fields := []struct {
+		name   string
+		value  string
+		length int
+	}{
+		{"name", description.Name, hmyStaking.MaxNameLength},
+		{"identity", description.Identity, hmyStaking.MaxIdentityLength},
+		{"website", description.Website, hmyStaking.MaxWebsiteLength},
+		{"security-contact", description.SecurityContact, hmyStaking.MaxSecurityContactLength},
+		{"details", description.Details, hmyStaking.MaxDetailsLength},
+	}
+
+	for _, field := range fields {
+		if len(field.value) > field.length {
+			return NewValidationError(directive, field.name, ErrDescriptionTooLong, "length: %d, maximum: %d", len(field.value), field.length)
+		}
+	}
+
+	return nil
+}
+
+// ValidateCommissionRates - checks that all rates are between 0.0 and 1.0 and that neither the rate nor the max change rate exceed the max rate
+func ValidateCommissionRates(directive hmyStaking.Directive, rates hmyStaking.CommissionRates) error {
+	if !isPercentage(rates.Rate) {
+		return NewValidationError(directive, "commission-rate", ErrInvalidCommissionRate, "rate: %s", rates.Rate)
+	}
+
+	if !isPercentage(rates.MaxRate) {
+		return NewValidationError(directive, "commission-max-rate", ErrInvalidCommissionRate, "max rate: %s", rates.MaxRate)
+	}
+
+	if !isPercentage(rates.MaxChangeRate) {
+		return NewValidationError(directive, "commission-max-change-rate", ErrInvalidCommissionRate, "max change rate: %s", rates.MaxChangeRate)
+	}
+
+	if rates.Rate.GT(rates.MaxRate) {
+		return NewValidationError(directive, "commission-rate", ErrCommissionRateTooLarge, "rate: %s, max rate: %s", rates.Rate, rates.MaxRate)
+	}
+
+	if rates.MaxChangeRate.GT(rates.MaxRate) {
+		return NewValidationError(directive, "commission-max-change-rate", ErrCommissionRateTooLarge, "max change rate: %s, max rate: %s", rates.MaxChangeRate, rates.MaxRate)
+	}
+
+	return nil
+}
+
+// ValidateBLSKey - verifies the signature of a BLS public key
+func ValidateBLSKey(directive hmyStaking.Directive, publicKey *bls.SerializedPublicKey, signature *bls.SerializedSignature) error {
+	if err := hmyStaking.VerifyBLSKey(publicKey, signature); err != nil {
+		return NewValidationError(directive, "bls-keys", ErrInvalidBLSKey, "key: %s", publicKey.Hex())
+	}
+
+	return nil
+}
+
+// ValidateBalance - checks that the balance of a given address covers amount plus the tx fee (in ONE)
+func ValidateBalance(ctx context.Context, client *rpc.Client, directive hmyStaking.Directive, address string, amount numeric.Dec, fee numeric.Dec) error {
+	return ValidateFunds(ctx, client, directive, address, amount, numeric.ZeroDec(), fee)
+}
+
+// ValidateFunds - checks that the balance of a given address plus additional funds (e.g. redelegatable tokens) cover amount and that the balance covers the tx fee (in ONE)
+// Additional funds can only be used for the amount, the fee (gas limit * gas price, see EstimateFee) is always paid from the balance
+func ValidateFunds(ctx context.Context, client *rpc.Client, directive hmyStaking.Directive, address string, amount numeric.Dec, additional numeric.Dec, fee numeric.Dec) error {
+	results, err := balances.GetBalancesWithContext(ctx, client, []string{address})
+	if err != nil {
+		return err
+	}
+
+	if results[0].Error != nil {
+		return results[0].Error
+	}
+
+	required := amount.Sub(additional)
+	if required.IsNegative() {
+		required = numeric.ZeroDec()
+	}
+	required = required.Add(fee)
+
+	if balance := results[0].Balance; balance.LT(required) {
+		return NewValidationError(directive, "amount", ErrInsufficientBalance, "address: %s, balance: %s, additional: %s, amount: %s, fee: %s", address, balance, additional, amount, fee)
+	}
+
+	return nil
+}
+
+func isPercentage(rate numeric.Dec) bool {
+	return !rate.IsNil() && !rate.IsNegative() && rate.LTE(numeric.OneDec())
+}
@@ -5,7 +5,7 @@ import (
 )
 
 // All - retrieves all validators
-func All(rpcClient *goSdkRPC.HTTPMessenger) (addresses []string, err error) {
+func All(rpcClient goSdkRPC.T) (addresses []string, err error) {
 	reply, err := rpcClient.SendRPC(goSdkRPC.Method.GetAllValidatorAddresses, []interface{}{})
 	if err != nil {
 		return nil, err
@@ -15,7 +15,7 @@ func All(rpcClient *goSdkRPC.HTTPMessenger) (addresses []string, err error) {
 }
 
 // Exists - checks if a given validator exists
-func Exists(rpcClient *goSdkRPC.HTTPMessenger, validatorAddress string) bool {
+func Exists(rpcClient goSdkRPC.T, validatorAddress string) bool {
 	allValidators, err := All(rpcClient)
 	if err == nil && len(allValidators) > 0 {
 		for _, address := range allValidators {
@@ -29,7 +29,7 @@ func Exists(rpcClient *goSdkRPC.HTTPMessenger, validatorAddress string) bool {
 }
 
 // AllElected - retrieves all active validators
-func AllElected(rpcClient *goSdkRPC.HTTPMessenger) ([]string, error) {
+func AllElected(rpcClient goSdkRPC.T) ([]string, error) {
 	reply, err := rpcClient.SendRPC(goSdkRPC.Method.GetElectedValidatorAddresses, []interface{}{})
 	if err != nil {
 		return nil, err
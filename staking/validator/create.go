@@ -1,11 +1,14 @@
 package validator
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/harmony-one/go-lib/crypto"
-	"github.com/harmony-one/go-lib/network"
+	"github.com/harmony-one/go-lib/logging"
+	"github.com/harmony-one/go-lib/signers"
 	"github.com/harmony-one/go-lib/staking"
+	"github.com/harmony-one/go-lib/transactions"
 	"github.com/harmony-one/go-sdk/pkg/address"
 	"github.com/harmony-one/go-sdk/pkg/common"
 	"github.com/harmony-one/go-sdk/pkg/rpc"
@@ -15,11 +18,21 @@ import (
 	hmyStaking "github.com/harmony-one/harmony/staking/types"
 )
 
+// CreateOptions - the validator details used by CreateWithTxContext
+type CreateOptions struct {
+	Description            hmyStaking.Description
+	CommissionRates        hmyStaking.CommissionRates
+	MinimumSelfDelegation  numeric.Dec
+	MaximumTotalDelegation numeric.Dec
+	BLSKeys                []crypto.BLSKey
+	Amount                 numeric.Dec
+}
+
 // Create - creates a validator
 func Create(
 	keystore *keystore.KeyStore,
 	account *accounts.Account,
-	rpcClient *rpc.HTTPMessenger,
+	rpcClient rpc.T,
 	chain *common.ChainID,
 	validatorAddress string,
 	description hmyStaking.Description,
@@ -34,14 +47,55 @@ func Create(
 	keystorePassphrase string,
 	node string,
 	timeout int,
-) (map[string]interface{}, error) {
-	payloadGenerator, err := createTransactionGenerator(validatorAddress, description, commissionRates, minimumSelfDelegation, maximumTotalDelegation, blsKeys, amount)
+) (*transactions.Receipt, error) {
+	return CreateWithSigner(signers.NewKeystoreSigner(keystore, account), rpcClient, chain, validatorAddress, description, commissionRates, minimumSelfDelegation, maximumTotalDelegation, blsKeys, amount, gasLimit, gasPrice, nonce, node, timeout)
+}
+
+// CreateWithSigner - creates a validator using a given signer
+func CreateWithSigner(
+	signer signers.Signer,
+	rpcClient rpc.T,
+	chain *common.ChainID,
+	validatorAddress string,
+	description hmyStaking.Description,
+	commissionRates hmyStaking.CommissionRates,
+	minimumSelfDelegation numeric.Dec,
+	maximumTotalDelegation numeric.Dec,
+	blsKeys []crypto.BLSKey,
+	amount numeric.Dec,
+	gasLimit int64,
+	gasPrice numeric.Dec,
+	nonce uint64,
+	node string,
+	timeout int,
+) (*transactions.Receipt, error) {
+	txContext := transactions.NewLegacyTxContext(signer, rpcClient, chain, gasLimit, gasPrice, nonce, node, timeout).WithFrom(validatorAddress).WithIgnoreWaitErrors(true)
+
+	options := CreateOptions{
+		Description:            description,
+		CommissionRates:        commissionRates,
+		MinimumSelfDelegation:  minimumSelfDelegation,
+		MaximumTotalDelegation: maximumTotalDelegation,
+		BLSKeys:                blsKeys,
+		Amount:                 amount,
+	}
+
+	return CreateWithTxContext(context.Background(), txContext, options)
+}
+
+// CreateWithTxContext - creates a validator using a given tx context, the tx context's sender is the validator address
+func CreateWithTxContext(ctx context.Context, txContext *transactions.TxContext, options CreateOptions) (*transactions.Receipt, error) {
+	validatorAddress := txContext.FromAddress()
+	description := options.Description
+	commissionRates := options.CommissionRates
+
+	payloadGenerator, err := createTransactionGenerator(validatorAddress, description, commissionRates, options.MinimumSelfDelegation, options.MaximumTotalDelegation, options.BLSKeys, options.Amount)
 	if err != nil {
 		return nil, err
 	}
 
 	var logMessage string
-	if network.Verbose {
+	if logging.Resolve(txContext.Logger, logging.FromContext(ctx)).Enabled(logging.DebugLevel) {
 		logMessage = fmt.Sprintf("Generating a new create validator transaction:\n\tValidator Address: %s\n\tValidator Name: %s\n\tValidator Identity: %s\n\tValidator Website: %s\n\tValidator Security Contact: %s\n\tValidator Details: %s\n\tCommission Rate: %f\n\tCommission Max Rate: %f\n\tCommission Max Change Rate: %d\n\tMinimum Self Delegation: %f\n\tMaximum Total Delegation: %f\n\tBls Public Keys: %v\n\tAmount: %f",
 			validatorAddress,
 			description.Name,
@@ -52,14 +106,14 @@ func Create(
 			commissionRates.Rate,
 			commissionRates.MaxRate,
 			commissionRates.MaxChangeRate,
-			minimumSelfDelegation,
-			maximumTotalDelegation,
-			blsKeys,
-			amount,
+			options.MinimumSelfDelegation,
+			options.MaximumTotalDelegation,
+			options.BLSKeys,
+			options.Amount,
 		)
 	}
 
-	return staking.SendTx(keystore, account, rpcClient, chain, gasLimit, gasPrice, nonce, keystorePassphrase, node, timeout, payloadGenerator, logMessage)
+	return staking.SendTxWithTxContext(ctx, txContext, payloadGenerator, logMessage)
 }
 
 func createTransactionGenerator(
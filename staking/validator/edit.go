@@ -1,12 +1,16 @@
 package validator
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
 	"github.com/harmony-one/go-lib/crypto"
-	"github.com/harmony-one/go-lib/network"
+	"github.com/harmony-one/go-lib/logging"
+	libRPC "github.com/harmony-one/go-lib/rpc"
+	"github.com/harmony-one/go-lib/signers"
 	"github.com/harmony-one/go-lib/staking"
+	"github.com/harmony-one/go-lib/transactions"
 	"github.com/harmony-one/go-sdk/pkg/address"
 	"github.com/harmony-one/go-sdk/pkg/common"
 	"github.com/harmony-one/go-sdk/pkg/rpc"
@@ -18,11 +22,22 @@ import (
 	hmyStaking "github.com/harmony-one/harmony/staking/types"
 )
 
+// EditOptions - the validator details used by EditWithTxContext, nil pointers leave the corresponding details unchanged
+type EditOptions struct {
+	Description            hmyStaking.Description
+	CommissionRate         *numeric.Dec
+	MinimumSelfDelegation  numeric.Dec
+	MaximumTotalDelegation numeric.Dec
+	BLSKeyToRemove         *crypto.BLSKey
+	BLSKeyToAdd            *crypto.BLSKey
+	Status                 string
+}
+
 // Edit - edits the details for an existing validator
 func Edit(
 	keystore *keystore.KeyStore,
 	account *accounts.Account,
-	rpcClient *rpc.HTTPMessenger,
+	rpcClient rpc.T,
 	chain *common.ChainID,
 	validatorAddress string,
 	description hmyStaking.Description,
@@ -38,16 +53,57 @@ func Edit(
 	keystorePassphrase string,
 	node string,
 	timeout int,
-) (map[string]interface{}, error) {
-	statusEnum := determineEposStatus(status)
+) (*transactions.Receipt, error) {
+	return EditWithSigner(signers.NewKeystoreSigner(keystore, account), rpcClient, chain, validatorAddress, description, commissionRate, minimumSelfDelegation, maximumTotalDelegation, blsKeyToRemove, blsKeyToAdd, status, gasLimit, gasPrice, nonce, node, timeout)
+}
+
+// EditWithSigner - edits the details for an existing validator using a given signer
+func EditWithSigner(
+	signer signers.Signer,
+	rpcClient rpc.T,
+	chain *common.ChainID,
+	validatorAddress string,
+	description hmyStaking.Description,
+	commissionRate *numeric.Dec,
+	minimumSelfDelegation numeric.Dec,
+	maximumTotalDelegation numeric.Dec,
+	blsKeyToRemove *crypto.BLSKey,
+	blsKeyToAdd *crypto.BLSKey,
+	status string,
+	gasLimit int64,
+	gasPrice numeric.Dec,
+	nonce uint64,
+	node string,
+	timeout int,
+) (*transactions.Receipt, error) {
+	txContext := transactions.NewLegacyTxContext(signer, rpcClient, chain, gasLimit, gasPrice, nonce, node, timeout).WithFrom(validatorAddress).WithIgnoreWaitErrors(true)
+
+	options := EditOptions{
+		Description:            description,
+		CommissionRate:         commissionRate,
+		MinimumSelfDelegation:  minimumSelfDelegation,
+		MaximumTotalDelegation: maximumTotalDelegation,
+		BLSKeyToRemove:         blsKeyToRemove,
+		BLSKeyToAdd:            blsKeyToAdd,
+		Status:                 status,
+	}
 
-	payloadGenerator, err := editTransactionGenerator(validatorAddress, description, commissionRate, minimumSelfDelegation, maximumTotalDelegation, blsKeyToRemove, blsKeyToAdd, statusEnum)
+	return EditWithTxContext(context.Background(), txContext, options)
+}
+
+// EditWithTxContext - edits the details for an existing validator using a given tx context, the tx context's sender is the validator address
+func EditWithTxContext(ctx context.Context, txContext *transactions.TxContext, options EditOptions) (*transactions.Receipt, error) {
+	validatorAddress := txContext.FromAddress()
+	description := options.Description
+	statusEnum := determineEposStatus(options.Status)
+
+	payloadGenerator, err := editTransactionGenerator(validatorAddress, description, options.CommissionRate, options.MinimumSelfDelegation, options.MaximumTotalDelegation, options.BLSKeyToRemove, options.BLSKeyToAdd, statusEnum)
 	if err != nil {
 		return nil, err
 	}
 
 	var logMessage string
-	if network.Verbose {
+	if logging.Resolve(txContext.Logger, logging.FromContext(ctx)).Enabled(logging.DebugLevel) {
 		logMessage = fmt.Sprintf("Generating a new edit validator transaction:\n\tValidator Address: %s\n\tValidator Name: %s\n\tValidator Identity: %s\n\tValidator Website: %s\n\tValidator Security Contact: %s\n\tValidator Details: %s\n\tCommission Rate: %v\n\tMinimum Self Delegation: %f\n\tMaximum Total Delegation: %f\n\tRemove BLS key: %v\n\tAdd BLS key: %v\n\tStatus: %v",
 			validatorAddress,
 			description.Name,
@@ -55,16 +111,20 @@ func Edit(
 			description.Website,
 			description.SecurityContact,
 			description.Details,
-			commissionRate,
-			minimumSelfDelegation,
-			maximumTotalDelegation,
-			blsKeyToRemove,
-			blsKeyToAdd,
+			options.CommissionRate,
+			options.MinimumSelfDelegation,
+			options.MaximumTotalDelegation,
+			options.BLSKeyToRemove,
+			options.BLSKeyToAdd,
 			statusEnum,
 		)
 	}
 
-	return staking.SendTx(keystore, account, rpcClient, chain, gasLimit, gasPrice, nonce, keystorePassphrase, node, timeout, payloadGenerator, logMessage)
+	check := func(ctx context.Context, client *libRPC.Client, fee numeric.Dec) error {
+		return ValidateEdit(ctx, client, validatorAddress, options)
+	}
+
+	return staking.SendCheckedTxWithTxContext(ctx, txContext, payloadGenerator, logMessage, check)
 }
 
 func determineEposStatus(status string) (statusEnum effective.Eligibility) {
@@ -124,7 +184,7 @@ func editTransactionGenerator(
 func EditStatus(
 	keystore *keystore.KeyStore,
 	account *accounts.Account,
-	rpcClient *rpc.HTTPMessenger,
+	rpcClient rpc.T,
 	chain *common.ChainID,
 	validatorAddress string,
 	status string,
@@ -134,20 +194,45 @@ func EditStatus(
 	keystorePassphrase string,
 	node string,
 	timeout int,
-) (map[string]interface{}, error) {
+) (*transactions.Receipt, error) {
+	return EditStatusWithSigner(signers.NewKeystoreSigner(keystore, account), rpcClient, chain, validatorAddress, status, gasLimit, gasPrice, nonce, node, timeout)
+}
+
+// EditStatusWithSigner - edits the validator status for an existing validator using a given signer
+func EditStatusWithSigner(
+	signer signers.Signer,
+	rpcClient rpc.T,
+	chain *common.ChainID,
+	validatorAddress string,
+	status string,
+	gasLimit int64,
+	gasPrice numeric.Dec,
+	nonce uint64,
+	node string,
+	timeout int,
+) (*transactions.Receipt, error) {
+	txContext := transactions.NewLegacyTxContext(signer, rpcClient, chain, gasLimit, gasPrice, nonce, node, timeout).WithFrom(validatorAddress).WithIgnoreWaitErrors(true)
+
+	return EditStatusWithTxContext(context.Background(), txContext, status)
+}
+
+// EditStatusWithTxContext - edits the validator status using a given tx context, the tx context's sender is the validator
+func EditStatusWithTxContext(ctx context.Context, txContext *transactions.TxContext, status string) (*transactions.Receipt, error) {
+	validatorAddress := txContext.FromAddress()
+
 	statusEnum := determineEposStatus(status)
 
 	payloadGenerator := editValidatorStatusGenerator(validatorAddress, statusEnum)
 
 	var logMessage string
-	if network.Verbose {
+	if logging.Resolve(txContext.Logger, logging.FromContext(ctx)).Enabled(logging.DebugLevel) {
 		logMessage = fmt.Sprintf("Generating a new edit validator status transaction:\n\tValidator Address: %s\n\tStatus: %v",
 			validatorAddress,
 			statusEnum,
 		)
 	}
 
-	return staking.SendTx(keystore, account, rpcClient, chain, gasLimit, gasPrice, nonce, keystorePassphrase, node, timeout, payloadGenerator, logMessage)
+	return staking.SendTxWithTxContext(ctx, txContext, payloadGenerator, logMessage)
 }
 
 func editValidatorStatusGenerator(
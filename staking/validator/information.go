@@ -1,20 +1,27 @@
 package validator
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
+	"github.com/harmony-one/go-lib/rpc"
 	goSdkRPC "github.com/harmony-one/go-sdk/pkg/rpc"
 )
 
 // AllInformationForBlock - get all validator info for a given block
 func AllInformationForBlock(node string, blockNumber int, fetchAllPages bool) ([]RPCValidatorResult, error) {
+	return AllInformationForBlockWithContext(context.Background(), rpc.NewClient(node), blockNumber, fetchAllPages)
+}
+
+// AllInformationForBlockWithContext - get all validator info for a given block using a given context and client
+func AllInformationForBlockWithContext(ctx context.Context, client *rpc.Client, blockNumber int, fetchAllPages bool) ([]RPCValidatorResult, error) {
 	validatorResults := []RPCValidatorResult{}
 
 	if fetchAllPages {
 		page := 0
 		for {
-			validatorPagedResults, err := allInformationRequest(node, page, blockNumber)
+			validatorPagedResults, err := allInformationRequest(ctx, client, page, blockNumber)
 			if err != nil {
 				return validatorResults, err
 			}
@@ -26,7 +33,7 @@ func AllInformationForBlock(node string, blockNumber int, fetchAllPages bool) ([
 			page++
 		}
 	} else {
-		validatorPagedResults, err := allInformationRequest(node, 0, blockNumber)
+		validatorPagedResults, err := allInformationRequest(ctx, client, 0, blockNumber)
 		if err != nil {
 			return validatorResults, err
 		}
@@ -38,12 +45,17 @@ func AllInformationForBlock(node string, blockNumber int, fetchAllPages bool) ([
 
 // AllInformation - retrieve all validator information
 func AllInformation(node string, fetchAllPages bool) ([]RPCValidatorResult, error) {
+	return AllInformationWithContext(context.Background(), rpc.NewClient(node), fetchAllPages)
+}
+
+// AllInformationWithContext - retrieve all validator information using a given context and client
+func AllInformationWithContext(ctx context.Context, client *rpc.Client, fetchAllPages bool) ([]RPCValidatorResult, error) {
 	validatorResults := []RPCValidatorResult{}
 
 	if fetchAllPages {
 		page := 0
 		for {
-			validatorPagedResults, err := allInformationRequest(node, page, -1)
+			validatorPagedResults, err := allInformationRequest(ctx, client, page, -1)
 			if err != nil {
 				return validatorResults, err
 			}
@@ -55,7 +67,7 @@ func AllInformation(node string, fetchAllPages bool) ([]RPCValidatorResult, erro
 			page++
 		}
 	} else {
-		validatorPagedResults, err := allInformationRequest(node, 0, -1)
+		validatorPagedResults, err := allInformationRequest(ctx, client, 0, -1)
 		if err != nil {
 			return validatorResults, err
 		}
@@ -65,7 +77,7 @@ func AllInformation(node string, fetchAllPages bool) ([]RPCValidatorResult, erro
 	return validatorResults, nil
 }
 
-func allInformationRequest(node string, page int, blockNumber int) ([]RPCValidatorResult, error) {
+func allInformationRequest(ctx context.Context, client *rpc.Client, page int, blockNumber int) ([]RPCValidatorResult, error) {
 	response := RPCValidatorInfosWrapper{}
 	results := []RPCValidatorResult{}
 	var bytes []byte
@@ -73,12 +85,12 @@ func allInformationRequest(node string, page int, blockNumber int) ([]RPCValidat
 
 	if blockNumber >= 0 {
 		hexBlockNumberParam := fmt.Sprintf("0x%x", blockNumber)
-		bytes, err = goSdkRPC.RawRequest(goSdkRPC.Method.GetAllValidatorInformationByBlockNumber, node, []interface{}{page, hexBlockNumberParam})
+		bytes, err = client.RawRequest(ctx, goSdkRPC.Method.GetAllValidatorInformationByBlockNumber, []interface{}{page, hexBlockNumberParam})
 		if err != nil {
 			return results, err
 		}
 	} else {
-		bytes, err = goSdkRPC.RawRequest(goSdkRPC.Method.GetAllValidatorInformation, node, []interface{}{page})
+		bytes, err = client.RawRequest(ctx, goSdkRPC.Method.GetAllValidatorInformation, []interface{}{page})
 		if err != nil {
 			return results, err
 		}
@@ -105,10 +117,15 @@ func allInformationRequest(node string, page int, blockNumber int) ([]RPCValidat
 
 // Information - get the validator information for a given address
 func Information(node string, validatorAddress string) (RPCValidatorResult, error) {
+	return InformationWithContext(context.Background(), rpc.NewClient(node), validatorAddress)
+}
+
+// InformationWithContext - get the validator information for a given address using a given context and client
+func InformationWithContext(ctx context.Context, client *rpc.Client, validatorAddress string) (RPCValidatorResult, error) {
 	response := RPCValidatorInfoWrapper{}
 	result := RPCValidatorResult{}
 
-	bytes, err := goSdkRPC.RawRequest(goSdkRPC.Method.GetValidatorInformation, node, []interface{}{validatorAddress})
+	bytes, err := client.RawRequest(ctx, goSdkRPC.Method.GetValidatorInformation, []interface{}{validatorAddress})
 	if err != nil {
 		return result, err
 	}
@@ -0,0 +1,38 @@
+package validator
+
+import (
+	"context"
+
+	"github.com/harmony-one/go-lib/rpc"
+	"github.com/harmony-one/go-lib/staking"
+	hmyStaking "github.com/harmony-one/harmony/staking/types"
+)
+
+// ValidateEdit - checks an edit against the validator's current on-chain details: a new commission rate can't exceed the max rate or differ from the current rate by more than the max change rate
+func ValidateEdit(ctx context.Context, client *rpc.Client, validatorAddress string, options EditOptions) error {
+	if options.CommissionRate == nil {
+		return nil
+	}
+
+	info, err := InformationWithContext(ctx, client, validatorAddress)
+	if err != nil {
+		return err
+	}
+
+	current := info.Validator
+	rate := *options.CommissionRate
+
+	if !current.MaxRate.IsNil() && rate.GT(current.MaxRate) {
+		return staking.NewValidationError(hmyStaking.DirectiveEditValidator, "commission-rate", staking.ErrCommissionRateTooLarge, "rate: %s, max rate: %s", rate, current.MaxRate)
+	}
+
+	if current.Rate.IsNil() || current.MaxChangeRate.IsNil() {
+		return nil
+	}
+
+	if change := rate.Sub(current.Rate).Abs(); change.GT(current.MaxChangeRate) {
+		return staking.NewValidationError(hmyStaking.DirectiveEditValidator, "commission-rate", staking.ErrCommissionRateChangeTooLarge, "rate: %s, current rate: %s, max change rate: %s", rate, current.Rate, current.MaxChangeRate)
+	}
+
+	return nil
+}
@@ -0,0 +1,200 @@
+package transactions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	libErrors "github.com/harmony-one/go-lib/errors"
+	"github.com/harmony-one/go-lib/logging"
+	"github.com/harmony-one/go-lib/rpc"
+	goSdkRPC "github.com/harmony-one/go-sdk/pkg/rpc"
+)
+
+// ConfirmationWatcher - watches one or more transactions until they've been confirmed, failed or the context is done
+type ConfirmationWatcher struct {
+	Client          *rpc.Client
+	Messenger       goSdkRPC.T     // Messenger - if set, receipts are fetched using the messenger while Client is only used for the error sinks and the block number
+	TxType          string         // TxType - "transaction" or "staking", determines which error sink is checked
+	PollInterval    time.Duration  // PollInterval - time to wait between every poll, defaults to 1 second
+	MaxPollInterval time.Duration  // MaxPollInterval - if larger than PollInterval the interval is doubled every tick up to MaxPollInterval
+	Confirmations   uint64         // Confirmations - number of blocks that have to be built on top of the tx block, 0 means included in a block
+	Logger          logging.Logger // Logger - overrides the logger of the context / the global logger
+}
+
+// ConfirmationResult - the outcome for a single watched transaction
+type ConfirmationResult struct {
+	TransactionHash string
+	Receipt         *Receipt
+	Error           error
+}
+
+// NewConfirmationWatcher - creates a new watcher polling every second for a given client and tx type
+func NewConfirmationWatcher(client *rpc.Client, txType string) *ConfirmationWatcher {
+	return &ConfirmationWatcher{
+		Client:       client,
+		TxType:       txType,
+		PollInterval: time.Second,
+	}
+}
+
+// Wait - waits for a single transaction to be confirmed
+func (watcher *ConfirmationWatcher) Wait(ctx context.Context, receiptHash string) (*Receipt, error) {
+	result := watcher.WaitAll(ctx, []string{receiptHash})[receiptHash]
+	return result.Receipt, result.Error
+}
+
+// WaitAll - waits for all of the given transactions to be confirmed and returns the results keyed by tx hash
+func (watcher *ConfirmationWatcher) WaitAll(ctx context.Context, receiptHashes []string) map[string]ConfirmationResult {
+	results := make(map[string]ConfirmationResult)
+
+	for result := range watcher.Watch(ctx, receiptHashes) {
+		results[result.TransactionHash] = result
+	}
+
+	return results
+}
+
+// Watch - watches the given transactions and delivers a result on the returned channel as soon as each transaction is resolved
+// The error sink is only polled once per tick regardless of the number of watched transactions
+// Transactions that haven't been resolved when the context is done are delivered with ErrConfirmationTimeout (deadline) or the context error (cancellation)
+func (watcher *ConfirmationWatcher) Watch(ctx context.Context, receiptHashes []string) <-chan ConfirmationResult {
+	results := make(chan ConfirmationResult, len(receiptHashes))
+
+	pending := make(map[string]bool)
+	for _, receiptHash := range receiptHashes {
+		pending[receiptHash] = true
+	}
+
+	go func() {
+		defer close(results)
+
+		interval := watcher.PollInterval
+		if interval <= 0 {
+			interval = time.Second
+		}
+
+		for {
+			watcher.poll(ctx, pending, results)
+			if len(pending) == 0 {
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				err := ctx.Err()
+				if errors.Is(err, context.DeadlineExceeded) {
+					err = libErrors.ErrConfirmationTimeout
+				}
+
+				for receiptHash := range pending {
+					results <- ConfirmationResult{TransactionHash: receiptHash, Error: err}
+				}
+				return
+			case <-time.After(interval):
+			}
+
+			if watcher.MaxPollInterval > interval {
+				interval *= 2
+				if interval > watcher.MaxPollInterval {
+					interval = watcher.MaxPollInterval
+				}
+			}
+		}
+	}()
+
+	return results
+}
+
+func (watcher *ConfirmationWatcher) poll(ctx context.Context, pending map[string]bool, results chan<- ConfirmationResult) {
+	var failures []rpc.Failure
+
+	switch watcher.TxType {
+	case "transaction":
+		failures, _ = rpc.TransactionFailuresWithContext(ctx, watcher.Client)
+	case "staking":
+		failures, _ = rpc.StakingFailuresWithContext(ctx, watcher.Client)
+	}
+
+	var currentBlock uint64
+	var currentBlockErr error
+	currentBlockFetched := false
+
+	for receiptHash := range pending {
+		if err := handleTransactionError(receiptHash, failures); err != nil {
+			logging.Resolve(watcher.Logger, logging.FromContext(ctx)).Log(logging.WarnLevel, fmt.Sprintf("%s error occurred", watcher.TxType), logging.TxHash(receiptHash), logging.Err(err))
+			delete(pending, receiptHash)
+			results <- ConfirmationResult{TransactionHash: receiptHash, Error: err}
+			continue
+		}
+
+		receipt, err := watcher.receipt(ctx, receiptHash)
+		if err != nil {
+			if ctx.Err() == nil && !rpc.IsRetryable(err) {
+				delete(pending, receiptHash)
+				results <- ConfirmationResult{TransactionHash: receiptHash, Error: err}
+			}
+			continue
+		}
+
+		if receipt == nil {
+			continue
+		}
+
+		if watcher.Confirmations > 0 {
+			if !currentBlockFetched {
+				currentBlock, currentBlockErr = rpc.GetCurrentBlockNumberWithContext(ctx, watcher.Client)
+				currentBlockFetched = true
+			}
+
+			if currentBlockErr != nil || currentBlock < receipt.BlockNumber+watcher.Confirmations {
+				continue
+			}
+		}
+
+		delete(pending, receiptHash)
+		results <- ConfirmationResult{TransactionHash: receiptHash, Receipt: receipt}
+	}
+}
+
+// receipt fetches the receipt for a given tx using the messenger if set, otherwise using the client
+func (watcher *ConfirmationWatcher) receipt(ctx context.Context, receiptHash string) (*Receipt, error) {
+	if watcher.Messenger != nil {
+		return GetTransactionReceipt(watcher.Messenger, receiptHash)
+	}
+
+	return GetTransactionReceiptWithContext(ctx, watcher.Client, receiptHash)
+}
+
+// GetTransactionReceiptWithContext - retrieves the receipt for a transaction using a given context and client, returns nil if the transaction hasn't been confirmed yet
+func GetTransactionReceiptWithContext(ctx context.Context, client *rpc.Client, receiptHash string) (*Receipt, error) {
+	response := struct {
+		Result *Receipt     `json:"result"`
+		Error  rpc.RPCError `json:"error,omitempty"`
+	}{}
+
+	bytes, err := client.RawRequest(ctx, goSdkRPC.Method.GetTransactionReceipt, []interface{}{receiptHash})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(bytes, &response); err != nil {
+		return nil, err
+	}
+
+	if response.Error.Message != "" {
+		return nil, response.Error
+	}
+
+	if response.Result == nil {
+		return nil, nil
+	}
+
+	if err := response.Result.Initialize(); err != nil {
+		return nil, err
+	}
+
+	return response.Result, nil
+}
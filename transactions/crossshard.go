@@ -0,0 +1,190 @@
+package transactions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	libErrors "github.com/harmony-one/go-lib/errors"
+	"github.com/harmony-one/go-lib/logging"
+	"github.com/harmony-one/go-lib/rpc"
+	"github.com/harmony-one/go-lib/signers"
+	"github.com/harmony-one/go-sdk/pkg/common"
+	"github.com/harmony-one/harmony/numeric"
+)
+
+// CXReceiptMethod - RPC method used to look up cross shard receipts on the destination shard
+const CXReceiptMethod = "hmyv2_getCXReceiptByHash"
+
+// CXReceipt - represents a cross shard receipt as returned by the hmyv2_getCXReceiptByHash RPC method
+type CXReceipt struct {
+	BlockHash       string   `json:"blockHash" yaml:"blockHash"`
+	BlockNumber     uint64   `json:"blockNumber" yaml:"blockNumber"`
+	TransactionHash string   `json:"hash" yaml:"hash"`
+	From            string   `json:"from" yaml:"from"`
+	To              string   `json:"to" yaml:"to"`
+	ShardID         uint32   `json:"shardID" yaml:"shardID"`
+	ToShardID       uint32   `json:"toShardID" yaml:"toShardID"`
+	Value           *big.Int `json:"value" yaml:"value"`
+}
+
+// CrossShardTransfer - the outcome of a cross shard transfer, including the timings of both legs
+type CrossShardTransfer struct {
+	TransactionHash    string
+	SourceReceipt      *Receipt
+	CXReceipt          *CXReceipt // CXReceipt - nil for transfers within the same shard
+	SentAt             time.Time
+	SourceConfirmedAt  time.Time
+	CreditedAt         time.Time     // CreditedAt - time the cx receipt was found on the destination shard
+	SourceLatency      time.Duration // SourceLatency - time between sending the tx and the source shard receipt
+	DestinationLatency time.Duration // DestinationLatency - time between the source shard receipt and the funds being credited on the destination shard
+	TotalLatency       time.Duration
+}
+
+// GetCXReceiptWithContext - retrieves the cross shard receipt for a given transaction from the destination shard, returns nil if the funds haven't been credited yet
+func GetCXReceiptWithContext(ctx context.Context, client *rpc.Client, txHash string) (*CXReceipt, error) {
+	response := struct {
+		Result *CXReceipt   `json:"result"`
+		Error  rpc.RPCError `json:"error,omitempty"`
+	}{}
+
+	bytes, err := client.RawRequest(ctx, CXReceiptMethod, []interface{}{txHash})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(bytes, &response); err != nil {
+		return nil, err
+	}
+
+	if response.Error.Message != "" {
+		return nil, response.Error
+	}
+
+	return response.Result, nil
+}
+
+// SendCrossShardTransferWithSigner - sends a transfer from fromShardID to toShardID and tracks it until the funds have been credited on the destination shard or the context is done
+func SendCrossShardTransferWithSigner(
+	ctx context.Context,
+	signer signers.Signer,
+	sourceClient *rpc.Client,
+	destinationClient *rpc.Client,
+	chain *common.ChainID,
+	fromAddress string,
+	fromShardID uint32,
+	toAddress string,
+	toShardID uint32,
+	amount numeric.Dec,
+	gasLimit int64,
+	gasPrice numeric.Dec,
+	nonce uint64,
+	inputData string,
+) (*CrossShardTransfer, error) {
+	if signer == nil {
+		return nil, libErrors.ErrMissingAccount
+	}
+
+	txContext := NewTxContext(signer, sourceClient, chain).WithFrom(fromAddress).WithGas(gasLimit, gasPrice).WithNonce(nonce)
+
+	return SendCrossShardTransferWithTxContext(ctx, txContext, destinationClient, fromShardID, toAddress, toShardID, amount, inputData)
+}
+
+// SendCrossShardTransferWithTxContext - sends a transfer using a given tx context (whose client has to be connected to the source shard) and tracks it until the funds have been credited on the destination shard or the context is done
+// The wait policy's poll interval is used for tracking both legs, its timeout is ignored - use the context to limit the tracking time
+// In dry-run mode the transfer isn't tracked, its SourceReceipt carries the DryRunResult
+func SendCrossShardTransferWithTxContext(
+	ctx context.Context,
+	txContext *TxContext,
+	destinationClient *rpc.Client,
+	fromShardID uint32,
+	toAddress string,
+	toShardID uint32,
+	amount numeric.Dec,
+	inputData string,
+) (*CrossShardTransfer, error) {
+	if txContext.Client == nil {
+		return nil, ErrMissingClient
+	}
+
+	sentAt := time.Now()
+
+	sendContext := txContext.WithWait(0)
+	sendContext.Messenger = nil
+
+	receipt, err := SendTransactionWithTxContext(ctx, sendContext, fromShardID, toAddress, toShardID, amount, inputData)
+	if err != nil {
+		return nil, err
+	}
+
+	if receipt.DryRun != nil {
+		return &CrossShardTransfer{TransactionHash: receipt.TransactionHash, SourceReceipt: receipt, SentAt: sentAt}, nil
+	}
+
+	return TrackCrossShardTransfer(ctx, txContext.Client, destinationClient, receipt.TransactionHash, fromShardID, toShardID, sentAt, txContext.Wait.PollInterval)
+}
+
+// TrackCrossShardTransfer - waits for the source shard receipt of an already sent transfer and then for the cx receipt on the destination shard
+// sentAt is used to calculate the source shard latency, pollInterval defaults to 1 second
+// Returns ErrConfirmationTimeout (together with the legs completed so far) if the context deadline is reached
+func TrackCrossShardTransfer(ctx context.Context, sourceClient *rpc.Client, destinationClient *rpc.Client, txHash string, fromShardID uint32, toShardID uint32, sentAt time.Time, pollInterval time.Duration) (*CrossShardTransfer, error) {
+	if pollInterval <= 0 {
+		pollInterval = time.Second
+	}
+
+	transfer := &CrossShardTransfer{TransactionHash: txHash, SentAt: sentAt}
+
+	watcher := NewConfirmationWatcher(sourceClient, "transaction")
+	watcher.PollInterval = pollInterval
+
+	receipt, err := watcher.Wait(ctx, txHash)
+	if err != nil {
+		return transfer, err
+	}
+
+	transfer.SourceReceipt = receipt
+	transfer.SourceConfirmedAt = time.Now()
+	transfer.SourceLatency = transfer.SourceConfirmedAt.Sub(sentAt)
+	transfer.TotalLatency = transfer.SourceLatency
+
+	if !receipt.IsSuccessful() {
+		return transfer, fmt.Errorf("transaction %s failed on the source shard %d", txHash, fromShardID)
+	}
+
+	if fromShardID == toShardID {
+		return transfer, nil
+	}
+
+	for {
+		cxReceipt, err := GetCXReceiptWithContext(ctx, destinationClient, txHash)
+		if err != nil {
+			// Only transport errors are polled again, decode errors and rejected requests would fail the same way on every poll
+			var syntaxError *json.SyntaxError
+			var typeError *json.UnmarshalTypeError
+			if errors.As(err, &syntaxError) || errors.As(err, &typeError) || (ctx.Err() == nil && !rpc.IsRetryable(err)) {
+				return transfer, err
+			}
+		} else if cxReceipt != nil {
+			transfer.CXReceipt = cxReceipt
+			transfer.CreditedAt = time.Now()
+			transfer.DestinationLatency = transfer.CreditedAt.Sub(transfer.SourceConfirmedAt)
+			transfer.TotalLatency = transfer.CreditedAt.Sub(sentAt)
+
+			logging.FromContext(ctx).Log(logging.InfoLevel, "cross shard transfer credited", logging.TxHash(txHash), logging.ShardID(fromShardID), logging.ToShardID(toShardID), logging.F("latency", transfer.TotalLatency))
+
+			return transfer, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return transfer, libErrors.ErrConfirmationTimeout
+			}
+			return transfer, ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package transactions
+
+import (
+	"math/big"
+
+	ethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/harmony-one/harmony/numeric"
+	hmyStaking "github.com/harmony-one/harmony/staking/types"
+)
+
+// DryRunResult - describes a tx that was generated and signed in dry-run mode but never sent
+type DryRunResult struct {
+	TransactionHash    string                `json:"transactionHash" yaml:"transactionHash"`
+	RawTransaction     string                `json:"rawTransaction" yaml:"rawTransaction"`           // RawTransaction - the encoded signed tx that would have been sent
+	SignedTransaction  interface{}           `json:"-" yaml:"-"`                                     // SignedTransaction - *types.Transaction, *types.EthTransaction or *hmyStaking.StakingTransaction
+	Directive          *hmyStaking.Directive `json:"directive,omitempty" yaml:"directive,omitempty"` // Directive - only set for staking transactions
+	Payload            interface{}           `json:"payload,omitempty" yaml:"payload,omitempty"`     // Payload - the decoded staking directive, only set for staking transactions
+	Nonce              uint64                `json:"nonce" yaml:"nonce"`
+	GasLimit           uint64                `json:"gasLimit" yaml:"gasLimit"`
+	GasPrice           numeric.Dec           `json:"gasPrice" yaml:"gasPrice"`         // GasPrice - the gas price (in nano)
+	EstimatedFee       numeric.Dec           `json:"estimatedFee" yaml:"estimatedFee"` // EstimatedFee - gas limit * gas price (in ONE)
+	RawPreflightErrors []string              `json:"preflightErrors,omitempty" yaml:"preflightErrors,omitempty"`
+	PreflightErrors    []error               `json:"-" yaml:"-"` // PreflightErrors - the pre-flight checks that failed, a dry run reports them instead of aborting
+}
+
+// signedTransaction - the methods shared by all signed tx types
+type signedTransaction interface {
+	Hash() ethCommon.Hash
+	Nonce() uint64
+	GasLimit() uint64
+	GasPrice() *big.Int
+}
+
+// NewDryRunResult - describes a given signed tx and its encoded signature
+func NewDryRunResult(signedTx interface{}, signature string) *DryRunResult {
+	result := &DryRunResult{
+		RawTransaction:    signature,
+		SignedTransaction: signedTx,
+		GasPrice:          numeric.ZeroDec(),
+		EstimatedFee:      numeric.ZeroDec(),
+	}
+
+	if tx, ok := signedTx.(signedTransaction); ok {
+		result.TransactionHash = tx.Hash().Hex()
+		result.Nonce = tx.Nonce()
+		result.GasLimit = tx.GasLimit()
+		result.GasPrice = numeric.NewDecFromBigInt(tx.GasPrice()).Quo(NanoAsDec)
+
+		fee := new(big.Int).Mul(tx.GasPrice(), new(big.Int).SetUint64(tx.GasLimit()))
+		result.EstimatedFee = numeric.NewDecFromBigInt(fee).Quo(OneAsDec)
+	}
+
+	if stakingTx, ok := signedTx.(*hmyStaking.StakingTransaction); ok {
+		directive := stakingTx.StakingType()
+		result.Directive = &directive
+		result.Payload = stakingTx.StakingMessage()
+	}
+
+	return result
+}
+
+// AddPreflightError - records a failed pre-flight check, nil errors are ignored
+func (result *DryRunResult) AddPreflightError(err error) {
+	if err == nil {
+		return
+	}
+
+	result.PreflightErrors = append(result.PreflightErrors, err)
+	result.RawPreflightErrors = append(result.RawPreflightErrors, err.Error())
+}
+
+// Passed - returns true if none of the pre-flight checks failed
+func (result *DryRunResult) Passed() bool {
+	return len(result.PreflightErrors) == 0
+}
@@ -1,12 +1,12 @@
 package transactions
 
 import (
-	"fmt"
+	"context"
 	"math/big"
-	"time"
 
 	libErrors "github.com/harmony-one/go-lib/errors"
-	"github.com/harmony-one/go-lib/network"
+	"github.com/harmony-one/go-lib/logging"
+	"github.com/harmony-one/go-lib/signers"
 	"github.com/harmony-one/go-sdk/pkg/address"
 	"github.com/harmony-one/go-sdk/pkg/common"
 	goSdkRPC "github.com/harmony-one/go-sdk/pkg/rpc"
@@ -18,60 +18,50 @@ import (
 )
 
 // SendEthTransaction - send eth transactions
-func SendEthTransaction(keystore *keystore.KeyStore, account *accounts.Account, rpcClient *goSdkRPC.HTTPMessenger, chain *common.ChainID, fromAddress string, toAddress string, amount numeric.Dec, gasLimit int64, gasPrice numeric.Dec, nonce uint64, inputData string, keystorePassphrase string, node string, timeout int) (map[string]interface{}, error) {
+func SendEthTransaction(keystore *keystore.KeyStore, account *accounts.Account, rpcClient goSdkRPC.T, chain *common.ChainID, fromAddress string, toAddress string, amount numeric.Dec, gasLimit int64, gasPrice numeric.Dec, nonce uint64, inputData string, keystorePassphrase string, node string, timeout int) (*Receipt, error) {
 	if keystore == nil || account == nil {
 		return nil, libErrors.ErrMissingAccount
 	}
 
-	signedTx, err := GenerateAndSignEthTransaction(
-		keystore,
-		account,
-		chain,
-		fromAddress,
-		toAddress,
-		amount,
-		gasLimit,
-		gasPrice,
-		nonce,
-		inputData,
-	)
-	if err != nil {
-		return nil, err
-	}
+	return SendEthTransactionWithSigner(signers.NewKeystoreSigner(keystore, account), rpcClient, chain, fromAddress, toAddress, amount, gasLimit, gasPrice, nonce, inputData, node, timeout)
+}
 
-	signature, err := EncodeSignature(signedTx)
-	if err != nil {
-		return nil, err
+// SendEthTransactionWithSigner - send eth transactions using a given signer
+func SendEthTransactionWithSigner(signer signers.Signer, rpcClient goSdkRPC.T, chain *common.ChainID, fromAddress string, toAddress string, amount numeric.Dec, gasLimit int64, gasPrice numeric.Dec, nonce uint64, inputData string, node string, timeout int) (*Receipt, error) {
+	if signer == nil {
+		return nil, libErrors.ErrMissingAccount
 	}
 
-	if network.Verbose {
-		fmt.Printf("\n[Harmony SDK]: %s - signed transaction using chain: %s (id: %d), signature: %v\n", time.Now().Format(network.LoggingTimeFormat), chain.Name, chain.Value, signature)
-		json, _ := signedTx.MarshalJSON()
-		fmt.Printf("%s\n", common.JSONPrettyFormat(string(json)))
-		fmt.Printf("\n[Harmony SDK]: %s - sending transaction using node: %s, chain: %s (id: %d), signature: %v, timeout: %d\n\n", time.Now().Format(network.LoggingTimeFormat), node, chain.Name, chain.Value, signature, timeout)
-	}
+	txContext := NewLegacyTxContext(signer, rpcClient, chain, gasLimit, gasPrice, nonce, node, timeout).WithFrom(fromAddress)
 
-	receiptHash, err := SendRawTransaction(rpcClient, signature)
-	if err != nil {
-		return nil, err
-	}
+	return SendEthTransactionWithTxContext(context.Background(), txContext, toAddress, amount, inputData)
+}
 
-	if timeout > 0 {
-		hash := receiptHash.(string)
-		result, err := WaitForTxConfirmation(rpcClient, node, "transaction", hash, timeout)
+// SendEthTransactionWithTxContext - send eth transactions using the signer, client, chain, gas, nonce and wait policy of a given tx context
+func SendEthTransactionWithTxContext(ctx context.Context, txContext *TxContext, toAddress string, amount numeric.Dec, inputData string) (*Receipt, error) {
+	return txContext.Send(ctx, signers.TypeEthTransaction, 0, 0, func(nonce uint64) (interface{}, error) {
+		signedTx, err := GenerateAndSignEthTransactionWithSigner(
+			txContext.Signer,
+			txContext.Chain,
+			txContext.FromAddress(),
+			toAddress,
+			amount,
+			txContext.GasLimit,
+			txContext.GasPrice,
+			nonce,
+			inputData,
+		)
 		if err != nil {
 			return nil, err
 		}
 
-		if result != nil {
-			return result, nil
+		if logger := txContext.logger(ctx); logger.Enabled(logging.DebugLevel) {
+			json, _ := signedTx.MarshalJSON()
+			logger.Log(logging.DebugLevel, "generated transaction", logging.F("transaction", common.JSONPrettyFormat(string(json))))
 		}
-	}
 
-	result := make(map[string]interface{})
-	result["transactionHash"] = receiptHash
-
-	return result, nil
+		return signedTx, nil
+	})
 }
 
 // GenerateAndSignEthTransaction - generates and signs a transaction based on the supplied tx params and keystore/account
@@ -86,13 +76,28 @@ func GenerateAndSignEthTransaction(
 	gasPrice numeric.Dec,
 	nonce uint64,
 	inputData string,
+) (tx *types.EthTransaction, err error) {
+	return GenerateAndSignEthTransactionWithSigner(signers.NewKeystoreSigner(keystore, account), chain, fromAddress, toAddress, amount, gasLimit, gasPrice, nonce, inputData)
+}
+
+// GenerateAndSignEthTransactionWithSigner - generates and signs a transaction based on the supplied tx params and signer
+func GenerateAndSignEthTransactionWithSigner(
+	signer signers.Signer,
+	chain *common.ChainID,
+	fromAddress string,
+	toAddress string,
+	amount numeric.Dec,
+	gasLimit int64,
+	gasPrice numeric.Dec,
+	nonce uint64,
+	inputData string,
 ) (tx *types.EthTransaction, err error) {
 	generatedTx, err := GenerateEthTransaction(fromAddress, toAddress, amount, gasLimit, gasPrice, nonce, inputData)
 	if err != nil {
 		return nil, err
 	}
 
-	tx, err = SignEthTransaction(keystore, account, generatedTx, chain.Value)
+	tx, err = signer.SignEthTx(generatedTx, chain.Value)
 	if err != nil {
 		return nil, err
 	}
@@ -115,17 +120,14 @@ func GenerateEthTransaction(
 		return nil, err
 	}
 
-	if network.Verbose {
-		fmt.Println(fmt.Sprintf("\n[Harmony SDK]: %s - Generating a new transaction:\n\tReceiver address: %s\n\tAmount: %f\n\tNonce: %d\n\tGas limit: %d\n\tGas price: %f\n\tData length (bytes): %d\n",
-			time.Now().Format(network.LoggingTimeFormat),
-			toAddress,
-			amount,
-			nonce,
-			calculatedGasLimit,
-			gasPrice,
-			len(inputData)),
-		)
-	}
+	logging.Debug(nil, "generating a new eth transaction",
+		logging.F("receiver", toAddress),
+		logging.F("amount", amount),
+		logging.Nonce(nonce),
+		logging.F("gas-limit", calculatedGasLimit),
+		logging.F("gas-price", gasPrice),
+		logging.F("data-length", len(inputData)),
+	)
 
 	tx = transaction.NewEthTransaction(
 		nonce,
@@ -0,0 +1,178 @@
+package offline
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	eth_hexutil "github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/harmony-one/go-lib/rpc"
+	"github.com/harmony-one/go-lib/transactions"
+	"github.com/harmony-one/go-sdk/pkg/address"
+	goSdkRPC "github.com/harmony-one/go-sdk/pkg/rpc"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/numeric"
+	hmyStaking "github.com/harmony-one/harmony/staking/types"
+)
+
+// TransactionSummary - human readable representation of an (un)signed transaction, used for reviewing txs before signing/broadcasting them
+type TransactionSummary struct {
+	Type            string      `json:"type" yaml:"type"`
+	TransactionHash string      `json:"transaction-hash" yaml:"transaction-hash"`
+	Signed          bool        `json:"signed" yaml:"signed"`
+	ChainID         string      `json:"chain-id,omitempty" yaml:"chain-id,omitempty"`
+	From            string      `json:"from,omitempty" yaml:"from,omitempty"`
+	To              string      `json:"to,omitempty" yaml:"to,omitempty"`
+	ShardID         uint32      `json:"shard-id" yaml:"shard-id"`
+	ToShardID       uint32      `json:"to-shard-id" yaml:"to-shard-id"`
+	Amount          numeric.Dec `json:"amount" yaml:"amount"`
+	Nonce           uint64      `json:"nonce" yaml:"nonce"`
+	GasLimit        uint64      `json:"gas-limit" yaml:"gas-limit"`
+	GasPrice        numeric.Dec `json:"gas-price" yaml:"gas-price"`
+	Data            string      `json:"data,omitempty" yaml:"data,omitempty"`
+	Directive       string      `json:"directive,omitempty" yaml:"directive,omitempty"`
+	StakingMessage  interface{} `json:"staking-message,omitempty" yaml:"staking-message,omitempty"`
+}
+
+// Broadcast - broadcasts a signed transaction and returns the transaction hash
+func (signed *SignedTransaction) Broadcast(ctx context.Context, client *rpc.Client) (string, error) {
+	return BroadcastRawTransaction(ctx, client, signed.Type, signed.RawTransaction)
+}
+
+// BroadcastRawTransaction - broadcasts a signed RLP hex encoded transaction of a given type and returns the transaction hash
+func BroadcastRawTransaction(ctx context.Context, client *rpc.Client, txType string, rawTransaction string) (string, error) {
+	method := goSdkRPC.Method.SendRawTransaction
+	if txType == TypeStaking {
+		method = goSdkRPC.Method.SendRawStakingTransaction
+	}
+
+	reply, err := client.Request(ctx, method, []interface{}{rawTransaction})
+	if err != nil {
+		return "", err
+	}
+
+	return transactions.ParseTransactionHash(reply)
+}
+
+// Decode - decodes an RLP hex encoded transaction of a given type into a human readable summary
+func Decode(txType string, rawTransaction string) (*TransactionSummary, error) {
+	bytes, err := eth_hexutil.Decode(rawTransaction)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := decodeRawTransaction(txType, bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &TransactionSummary{Type: txType}
+
+	switch typedTx := tx.(type) {
+	case *types.Transaction:
+		summary.TransactionHash = typedTx.Hash().Hex()
+		summary.ShardID = typedTx.ShardID()
+		summary.ToShardID = typedTx.ToShardID()
+		summary.To = toBech32(typedTx.To())
+		summary.Amount = toDenomination(typedTx.Value(), transactions.OneAsDec)
+		summary.Nonce = typedTx.Nonce()
+		summary.GasLimit = typedTx.GasLimit()
+		summary.GasPrice = toDenomination(typedTx.GasPrice(), transactions.NanoAsDec)
+		summary.Data = encodeData(typedTx.Data())
+		if v, _, _ := typedTx.RawSignatureValues(); v != nil && v.Sign() != 0 {
+			summary.Signed = true
+			summary.ChainID = typedTx.ChainID().String()
+			if sender, err := types.Sender(types.NewEIP155Signer(typedTx.ChainID()), typedTx); err == nil {
+				summary.From = address.ToBech32(sender)
+			}
+		}
+	case *types.EthTransaction:
+		summary.TransactionHash = typedTx.Hash().Hex()
+		summary.ShardID = typedTx.ShardID()
+		summary.ToShardID = typedTx.ToShardID()
+		summary.To = toBech32(typedTx.To())
+		summary.Amount = toDenomination(typedTx.Value(), transactions.OneAsDec)
+		summary.Nonce = typedTx.Nonce()
+		summary.GasLimit = typedTx.GasLimit()
+		summary.GasPrice = toDenomination(typedTx.GasPrice(), transactions.NanoAsDec)
+		summary.Data = encodeData(typedTx.Data())
+		if v, _, _ := typedTx.RawSignatureValues(); v != nil && v.Sign() != 0 {
+			summary.Signed = true
+			summary.ChainID = typedTx.ChainID().String()
+			if sender, err := types.Sender(types.NewEIP155Signer(typedTx.ChainID()), typedTx); err == nil {
+				summary.From = address.ToBech32(sender)
+			}
+		}
+	case *hmyStaking.StakingTransaction:
+		summary.TransactionHash = typedTx.Hash().Hex()
+		summary.ShardID = typedTx.ShardID()
+		summary.ToShardID = typedTx.ToShardID()
+		summary.Amount = numeric.ZeroDec()
+		summary.Nonce = typedTx.Nonce()
+		summary.GasLimit = typedTx.GasLimit()
+		summary.GasPrice = toDenomination(typedTx.GasPrice(), transactions.NanoAsDec)
+		summary.Directive = typedTx.StakingType().String()
+
+		payload, err := typedTx.RLPEncodeStakeMsg()
+		if err != nil {
+			return nil, err
+		}
+		if summary.StakingMessage, err = hmyStaking.RLPDecodeStakeMsg(payload, typedTx.StakingType()); err != nil {
+			return nil, err
+		}
+
+		if v, _, _ := typedTx.RawSignatureValues(); v != nil && v.Sign() != 0 {
+			summary.Signed = true
+			summary.ChainID = typedTx.ChainID().String()
+			if sender, err := hmyStaking.Sender(hmyStaking.NewEIP155Signer(typedTx.ChainID()), typedTx); err == nil {
+				summary.From = address.ToBech32(sender)
+			}
+		}
+	}
+
+	return summary, nil
+}
+
+func decodeRawTransaction(txType string, bytes []byte) (tx interface{}, err error) {
+	switch txType {
+	case TypeTransaction:
+		tx = new(types.Transaction)
+	case TypeEthTransaction:
+		tx = new(types.EthTransaction)
+	case TypeStaking:
+		tx = new(hmyStaking.StakingTransaction)
+	default:
+		return nil, fmt.Errorf("unsupported transaction type %q", txType)
+	}
+
+	if err := rlp.DecodeBytes(bytes, tx); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+func toBech32(to *address.T) string {
+	if to == nil {
+		return ""
+	}
+
+	return address.ToBech32(*to)
+}
+
+func toDenomination(value *big.Int, denomination numeric.Dec) numeric.Dec {
+	if value == nil {
+		return numeric.ZeroDec()
+	}
+
+	return numeric.NewDecFromBigInt(value).Quo(denomination)
+}
+
+func encodeData(data []byte) string {
+	if len(data) == 0 {
+		return ""
+	}
+
+	return eth_hexutil.Encode(data)
+}
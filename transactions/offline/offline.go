@@ -0,0 +1,159 @@
+package offline
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+
+	eth_hexutil "github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/harmony-one/go-lib/signers"
+	"github.com/harmony-one/go-lib/staking"
+	"github.com/harmony-one/go-lib/transactions"
+	"github.com/harmony-one/go-sdk/pkg/common"
+	"github.com/harmony-one/harmony/numeric"
+	hmyStaking "github.com/harmony-one/harmony/staking/types"
+)
+
+// Supported transaction types
+const (
+	TypeTransaction    = signers.TypeTransaction
+	TypeEthTransaction = signers.TypeEthTransaction
+	TypeStaking        = signers.TypeStaking
+)
+
+// UnsignedTransaction - portable representation of an unsigned transaction that can be signed on an air-gapped machine
+type UnsignedTransaction struct {
+	Type           string              `json:"type" yaml:"type"`
+	ChainID        string              `json:"chain-id" yaml:"chain-id"`
+	RawTransaction string              `json:"raw-transaction" yaml:"raw-transaction"`     // RawTransaction - RLP hex of the unsigned tx
+	Summary        *TransactionSummary `json:"summary,omitempty" yaml:"summary,omitempty"` // Summary - informational only, never used for signing
+}
+
+// SignedTransaction - portable representation of a signed transaction that can be broadcasted later
+type SignedTransaction struct {
+	Type            string `json:"type" yaml:"type"`
+	ChainID         string `json:"chain-id" yaml:"chain-id"`
+	TransactionHash string `json:"transaction-hash" yaml:"transaction-hash"`
+	RawTransaction  string `json:"raw-transaction" yaml:"raw-transaction"` // RawTransaction - RLP hex of the signed tx, as accepted by SendRawTransaction / SendRawStakingTransaction
+}
+
+// NewUnsignedTransaction - builds an unsigned regular transaction
+func NewUnsignedTransaction(chain *common.ChainID, fromAddress string, fromShardID uint32, toAddress string, toShardID uint32, amount numeric.Dec, gasLimit int64, gasPrice numeric.Dec, nonce uint64, inputData string) (*UnsignedTransaction, error) {
+	tx, err := transactions.GenerateTransaction(fromAddress, fromShardID, toAddress, toShardID, amount, gasLimit, gasPrice, nonce, inputData)
+	if err != nil {
+		return nil, err
+	}
+
+	return newUnsignedTransaction(TypeTransaction, chain, tx)
+}
+
+// NewUnsignedEthTransaction - builds an unsigned eth compatible transaction
+func NewUnsignedEthTransaction(chain *common.ChainID, fromAddress string, toAddress string, amount numeric.Dec, gasLimit int64, gasPrice numeric.Dec, nonce uint64, inputData string) (*UnsignedTransaction, error) {
+	tx, err := transactions.GenerateEthTransaction(fromAddress, toAddress, amount, gasLimit, gasPrice, nonce, inputData)
+	if err != nil {
+		return nil, err
+	}
+
+	return newUnsignedTransaction(TypeEthTransaction, chain, tx)
+}
+
+// NewUnsignedStakingTransaction - builds an unsigned staking transaction using a given payload generator (e.g. a create validator or delegate directive)
+func NewUnsignedStakingTransaction(chain *common.ChainID, gasLimit int64, gasPrice numeric.Dec, nonce uint64, payloadGenerator hmyStaking.StakeMsgFulfiller) (*UnsignedTransaction, error) {
+	tx, _, err := staking.GenerateStakingTransaction(gasLimit, gasPrice, nonce, payloadGenerator)
+	if err != nil {
+		return nil, err
+	}
+
+	return newUnsignedTransaction(TypeStaking, chain, tx)
+}
+
+func newUnsignedTransaction(txType string, chain *common.ChainID, tx interface{}) (*UnsignedTransaction, error) {
+	if chain == nil || chain.Value == nil {
+		return nil, fmt.Errorf("a chain id is required to build an unsigned %s", txType)
+	}
+
+	rawTransaction, err := transactions.EncodeSignature(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	unsigned := &UnsignedTransaction{
+		Type:           txType,
+		ChainID:        chain.Value.String(),
+		RawTransaction: *rawTransaction,
+	}
+
+	if unsigned.Summary, err = Decode(txType, *rawTransaction); err != nil {
+		return nil, err
+	}
+	unsigned.Summary.ChainID = unsigned.ChainID
+
+	return unsigned, nil
+}
+
+// WriteFile - writes the unsigned transaction as JSON to a given path
+func (unsigned *UnsignedTransaction) WriteFile(path string) error {
+	return writeJSON(path, unsigned)
+}
+
+// WriteFile - writes the signed transaction as JSON to a given path
+func (signed *SignedTransaction) WriteFile(path string) error {
+	return writeJSON(path, signed)
+}
+
+// ReadUnsignedTransaction - reads an unsigned transaction from a given path
+func ReadUnsignedTransaction(path string) (*UnsignedTransaction, error) {
+	unsigned := &UnsignedTransaction{}
+	if err := readJSON(path, unsigned); err != nil {
+		return nil, err
+	}
+
+	return unsigned, nil
+}
+
+// ReadSignedTransaction - reads a signed transaction from a given path
+func ReadSignedTransaction(path string) (*SignedTransaction, error) {
+	signed := &SignedTransaction{}
+	if err := readJSON(path, signed); err != nil {
+		return nil, err
+	}
+
+	return signed, nil
+}
+
+func (unsigned *UnsignedTransaction) chainID() (*big.Int, error) {
+	chainID, ok := new(big.Int).SetString(unsigned.ChainID, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid chain id %q", unsigned.ChainID)
+	}
+
+	return chainID, nil
+}
+
+func (unsigned *UnsignedTransaction) decode() (interface{}, error) {
+	bytes, err := eth_hexutil.Decode(unsigned.RawTransaction)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeRawTransaction(unsigned.Type, bytes)
+}
+
+func writeJSON(path string, value interface{}) error {
+	bytes, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, bytes, 0600)
+}
+
+func readJSON(path string, value interface{}) error {
+	bytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(bytes, value)
+}
@@ -0,0 +1,114 @@
+package offline_test
+
+import (
+	"encoding/hex"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	ethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/harmony-one/go-lib/transactions/offline"
+	"github.com/harmony-one/go-sdk/pkg/address"
+	"github.com/harmony-one/go-sdk/pkg/common"
+	"github.com/harmony-one/harmony/numeric"
+	hmyStaking "github.com/harmony-one/harmony/staking/types"
+)
+
+func TestOfflineRoundTrip(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("failed to generate a key: %v", err)
+	}
+	privateKey := hex.EncodeToString(crypto.FromECDSA(key))
+	from := address.ToBech32(crypto.PubkeyToAddress(key.PublicKey))
+	to := address.ToBech32(ethCommon.HexToAddress("0x2222222222222222222222222222222222222222"))
+
+	amount := numeric.MustNewDecFromStr("1.5")
+
+	regular, err := offline.NewUnsignedTransaction(&common.Chain.TestNet, from, 0, to, 1, amount, 21000, numeric.NewDec(2), 3, "")
+	if err != nil {
+		t.Fatalf("failed to build the transaction: %v", err)
+	}
+
+	delegate := func() (hmyStaking.Directive, interface{}) {
+		return hmyStaking.DirectiveDelegate, hmyStaking.Delegate{
+			DelegatorAddress: address.Parse(from),
+			ValidatorAddress: address.Parse(to),
+			Amount:           big.NewInt(1000),
+		}
+	}
+
+	// Staking txs are generated using a gas price in wei, 2 Gwei
+	staking, err := offline.NewUnsignedStakingTransaction(&common.Chain.TestNet, 25000, numeric.NewDec(2000000000), 4, delegate)
+	if err != nil {
+		t.Fatalf("failed to build the staking transaction: %v", err)
+	}
+
+	testCases := []struct {
+		name     string
+		unsigned *offline.UnsignedTransaction
+		amount   numeric.Dec
+		nonce    uint64
+	}{
+		{name: "transaction", unsigned: regular, amount: amount, nonce: 3},
+		{name: "staking", unsigned: staking, amount: numeric.ZeroDec(), nonce: 4},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			dir := t.TempDir()
+
+			if testCase.unsigned.Summary.Signed {
+				t.Error("expected the unsigned summary to be unsigned")
+			}
+
+			unsignedPath := filepath.Join(dir, "unsigned.json")
+			if err := testCase.unsigned.WriteFile(unsignedPath); err != nil {
+				t.Fatalf("failed to write the unsigned transaction: %v", err)
+			}
+
+			unsigned, err := offline.ReadUnsignedTransaction(unsignedPath)
+			if err != nil {
+				t.Fatalf("failed to read the unsigned transaction: %v", err)
+			}
+
+			signed, err := unsigned.SignWithPrivateKey(privateKey)
+			if err != nil {
+				t.Fatalf("failed to sign the transaction: %v", err)
+			}
+
+			signedPath := filepath.Join(dir, "signed.json")
+			if err := signed.WriteFile(signedPath); err != nil {
+				t.Fatalf("failed to write the signed transaction: %v", err)
+			}
+
+			signed, err = offline.ReadSignedTransaction(signedPath)
+			if err != nil {
+				t.Fatalf("failed to read the signed transaction: %v", err)
+			}
+
+			summary, err := offline.Decode(signed.Type, signed.RawTransaction)
+			if err != nil {
+				t.Fatalf("failed to decode the signed transaction: %v", err)
+			}
+
+			if !summary.Signed || summary.From != from || summary.ChainID != common.Chain.TestNet.Value.String() {
+				t.Errorf("expected a tx signed by %s on chain %s, got %+v", from, common.Chain.TestNet.Value, summary)
+			}
+
+			if summary.TransactionHash != signed.TransactionHash {
+				t.Errorf("expected the hash %s, got %s", signed.TransactionHash, summary.TransactionHash)
+			}
+
+			if summary.Nonce != testCase.nonce || !summary.Amount.Equal(testCase.amount) {
+				t.Errorf("expected nonce %d and amount %s, got %d and %s", testCase.nonce, testCase.amount, summary.Nonce, summary.Amount)
+			}
+
+			// The gas price is reported in Gwei for every tx type
+			if !summary.GasPrice.Equal(numeric.NewDec(2)) || !unsigned.Summary.GasPrice.Equal(numeric.NewDec(2)) {
+				t.Errorf("expected a gas price of 2 Gwei, got %s (unsigned: %s)", summary.GasPrice, unsigned.Summary.GasPrice)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package offline
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+
+	libErrors "github.com/harmony-one/go-lib/errors"
+	"github.com/harmony-one/go-lib/signers"
+	"github.com/harmony-one/go-lib/transactions"
+	"github.com/harmony-one/harmony/accounts"
+	"github.com/harmony-one/harmony/accounts/keystore"
+	"github.com/harmony-one/harmony/core/types"
+	hmyStaking "github.com/harmony-one/harmony/staking/types"
+)
+
+// SignWithKeystore - signs an unsigned transaction using an unlocked keystore / account
+func (unsigned *UnsignedTransaction) SignWithKeystore(keystore *keystore.KeyStore, account *accounts.Account) (*SignedTransaction, error) {
+	if keystore == nil || account == nil {
+		return nil, libErrors.ErrMissingAccount
+	}
+
+	return unsigned.SignWithSigner(signers.NewKeystoreSigner(keystore, account))
+}
+
+// SignWithPrivateKey - signs an unsigned transaction using a hex encoded raw private key
+func (unsigned *UnsignedTransaction) SignWithPrivateKey(privateKey string) (*SignedTransaction, error) {
+	signer, err := signers.NewPrivateKeySigner(privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return unsigned.SignWithSigner(signer)
+}
+
+// SignWithECDSAKey - signs an unsigned transaction using an ECDSA private key
+func (unsigned *UnsignedTransaction) SignWithECDSAKey(key *ecdsa.PrivateKey) (*SignedTransaction, error) {
+	return unsigned.SignWithSigner(signers.NewECDSAKeySigner(key))
+}
+
+// SignWithSigner - signs an unsigned transaction using a given signer
+func (unsigned *UnsignedTransaction) SignWithSigner(signer signers.Signer) (*SignedTransaction, error) {
+	if signer == nil {
+		return nil, libErrors.ErrMissingAccount
+	}
+
+	chainID, err := unsigned.chainID()
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := unsigned.decode()
+	if err != nil {
+		return nil, err
+	}
+
+	var signedTx interface{}
+
+	switch typedTx := tx.(type) {
+	case *types.Transaction:
+		signedTx, err = signer.SignTx(typedTx, chainID)
+	case *types.EthTransaction:
+		signedTx, err = signer.SignEthTx(typedTx, chainID)
+	case *hmyStaking.StakingTransaction:
+		signedTx, err = signer.SignStakingTx(typedTx, chainID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return unsigned.toSignedTransaction(signedTx)
+}
+
+func (unsigned *UnsignedTransaction) toSignedTransaction(signedTx interface{}) (*SignedTransaction, error) {
+	if signedTx == nil {
+		return nil, fmt.Errorf("unsupported transaction type %q", unsigned.Type)
+	}
+
+	rawTransaction, err := transactions.EncodeSignature(signedTx)
+	if err != nil {
+		return nil, err
+	}
+
+	summary, err := Decode(unsigned.Type, *rawTransaction)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SignedTransaction{
+		Type:            unsigned.Type,
+		ChainID:         unsigned.ChainID,
+		TransactionHash: summary.TransactionHash,
+		RawTransaction:  *rawTransaction,
+	}, nil
+}
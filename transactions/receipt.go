@@ -0,0 +1,114 @@
+package transactions
+
+import (
+	"github.com/harmony-one/go-lib/utils"
+	hmyStaking "github.com/harmony-one/harmony/staking/types"
+	"github.com/pkg/errors"
+)
+
+// Receipt - represents a transaction receipt as returned by the GetTransactionReceipt RPC method
+type Receipt struct {
+	TransactionHash      string                `json:"transactionHash" yaml:"transactionHash"`
+	BlockHash            string                `json:"blockHash,omitempty" yaml:"blockHash,omitempty"`
+	RawBlockNumber       string                `json:"blockNumber,omitempty" yaml:"blockNumber,omitempty"`
+	BlockNumber          uint64                `json:"-" yaml:"-"`
+	RawTransactionIndex  string                `json:"transactionIndex,omitempty" yaml:"transactionIndex,omitempty"`
+	TransactionIndex     uint64                `json:"-" yaml:"-"`
+	RawStatus            string                `json:"status,omitempty" yaml:"status,omitempty"`
+	Status               uint64                `json:"-" yaml:"-"`
+	RawGasUsed           string                `json:"gasUsed,omitempty" yaml:"gasUsed,omitempty"`
+	GasUsed              uint64                `json:"-" yaml:"-"`
+	RawCumulativeGasUsed string                `json:"cumulativeGasUsed,omitempty" yaml:"cumulativeGasUsed,omitempty"`
+	CumulativeGasUsed    uint64                `json:"-" yaml:"-"`
+	ContractAddress      string                `json:"contractAddress,omitempty" yaml:"contractAddress,omitempty"`
+	From                 string                `json:"from,omitempty" yaml:"from,omitempty"`
+	To                   string                `json:"to,omitempty" yaml:"to,omitempty"`
+	Sender               string                `json:"sender,omitempty" yaml:"sender,omitempty"` // Sender - only set for staking transactions
+	Type                 *hmyStaking.Directive `json:"type,omitempty" yaml:"type,omitempty"`     // Type - only set for staking transactions
+	ShardID              uint32                `json:"shardID" yaml:"shardID"`
+	ToShardID            uint32                `json:"toShardID" yaml:"toShardID"`
+	Logs                 []ReceiptLog          `json:"logs,omitempty" yaml:"logs,omitempty"`
+	DryRun               *DryRunResult         `json:"dryRun,omitempty" yaml:"dryRun,omitempty"` // DryRun - only set for txs generated in dry-run mode, which are never sent
+}
+
+// ReceiptLog - represents a log entry emitted while executing a transaction
+type ReceiptLog struct {
+	Address             string   `json:"address" yaml:"address"`
+	Topics              []string `json:"topics" yaml:"topics"`
+	Data                string   `json:"data" yaml:"data"`
+	RawBlockNumber      string   `json:"blockNumber,omitempty" yaml:"blockNumber,omitempty"`
+	BlockNumber         uint64   `json:"-" yaml:"-"`
+	BlockHash           string   `json:"blockHash,omitempty" yaml:"blockHash,omitempty"`
+	TransactionHash     string   `json:"transactionHash,omitempty" yaml:"transactionHash,omitempty"`
+	RawTransactionIndex string   `json:"transactionIndex,omitempty" yaml:"transactionIndex,omitempty"`
+	TransactionIndex    uint64   `json:"-" yaml:"-"`
+	RawLogIndex         string   `json:"logIndex,omitempty" yaml:"logIndex,omitempty"`
+	LogIndex            uint64   `json:"-" yaml:"-"`
+	Removed             bool     `json:"removed,omitempty" yaml:"removed,omitempty"`
+}
+
+// Initialize - initialize and convert values for a given Receipt struct
+func (receipt *Receipt) Initialize() (err error) {
+	if receipt.BlockNumber, err = hexToUint64(receipt.RawBlockNumber); err != nil {
+		return errors.Wrapf(err, "Receipt: BlockNumber")
+	}
+
+	if receipt.TransactionIndex, err = hexToUint64(receipt.RawTransactionIndex); err != nil {
+		return errors.Wrapf(err, "Receipt: TransactionIndex")
+	}
+
+	if receipt.Status, err = hexToUint64(receipt.RawStatus); err != nil {
+		return errors.Wrapf(err, "Receipt: Status")
+	}
+
+	if receipt.GasUsed, err = hexToUint64(receipt.RawGasUsed); err != nil {
+		return errors.Wrapf(err, "Receipt: GasUsed")
+	}
+
+	if receipt.CumulativeGasUsed, err = hexToUint64(receipt.RawCumulativeGasUsed); err != nil {
+		return errors.Wrapf(err, "Receipt: CumulativeGasUsed")
+	}
+
+	for i := range receipt.Logs {
+		if err := receipt.Logs[i].Initialize(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Initialize - initialize and convert values for a given ReceiptLog struct
+func (log *ReceiptLog) Initialize() (err error) {
+	if log.BlockNumber, err = hexToUint64(log.RawBlockNumber); err != nil {
+		return errors.Wrapf(err, "ReceiptLog: BlockNumber")
+	}
+
+	if log.TransactionIndex, err = hexToUint64(log.RawTransactionIndex); err != nil {
+		return errors.Wrapf(err, "ReceiptLog: TransactionIndex")
+	}
+
+	if log.LogIndex, err = hexToUint64(log.RawLogIndex); err != nil {
+		return errors.Wrapf(err, "ReceiptLog: LogIndex")
+	}
+
+	return nil
+}
+
+// IsConfirmed - checks if the receipt has been included in a block
+func (receipt *Receipt) IsConfirmed() bool {
+	return receipt.BlockHash != "" && receipt.RawStatus != ""
+}
+
+// IsSuccessful - checks if the transaction was included in a block and executed successfully
+func (receipt *Receipt) IsSuccessful() bool {
+	return receipt.IsConfirmed() && receipt.Status == 1
+}
+
+func hexToUint64(hex string) (uint64, error) {
+	if hex == "" {
+		return 0, nil
+	}
+
+	return utils.HexToDecimal(hex)
+}
@@ -0,0 +1,195 @@
+package transactions
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	libErrors "github.com/harmony-one/go-lib/errors"
+	"github.com/harmony-one/go-lib/logging"
+	"github.com/harmony-one/go-lib/signers"
+	"github.com/harmony-one/go-sdk/pkg/common"
+	goSdkRPC "github.com/harmony-one/go-sdk/pkg/rpc"
+	"github.com/harmony-one/harmony/accounts"
+	"github.com/harmony-one/harmony/accounts/keystore"
+	"github.com/harmony-one/harmony/numeric"
+)
+
+var (
+	// DefaultReplacementTimeout - time to wait for a tx to be confirmed before replacing it if ReplacementPolicy.Timeout isn't set
+	DefaultReplacementTimeout = 30 * time.Second
+)
+
+// ReplacementPolicy - settings for replacing transactions that are stuck in the tx pool
+type ReplacementPolicy struct {
+	Timeout     time.Duration // Timeout - time to wait for a tx to be confirmed before replacing it
+	MaxAttempts int           // MaxAttempts - maximum number of replacement txs to send
+	MaxGasPrice numeric.Dec   // MaxGasPrice - gas price ceiling, replacement stops once a bumped gas price would exceed it (nil means no ceiling)
+}
+
+// ReplaceStuckTransaction - waits for a pending tx to be confirmed and re-signs/resubmits it using the same nonce and a bumped gas price every time it isn't confirmed within policy.Timeout
+func ReplaceStuckTransaction(
+	keystore *keystore.KeyStore,
+	account *accounts.Account,
+	rpcClient goSdkRPC.T,
+	chain *common.ChainID,
+	fromAddress string,
+	fromShardID uint32,
+	toAddress string,
+	toShardID uint32,
+	amount numeric.Dec,
+	gasLimit int64,
+	gasPrice numeric.Dec,
+	nonce uint64,
+	inputData string,
+	node string,
+	txHash string,
+	policy ReplacementPolicy,
+) (*Receipt, error) {
+	if keystore == nil || account == nil {
+		return nil, libErrors.ErrMissingAccount
+	}
+
+	return ReplaceStuckTransactionWithSigner(signers.NewKeystoreSigner(keystore, account), rpcClient, chain, fromAddress, fromShardID, toAddress, toShardID, amount, gasLimit, gasPrice, nonce, inputData, node, txHash, policy)
+}
+
+// ReplaceStuckTransactionWithSigner - same as ReplaceStuckTransaction but re-signs the replacement txs using a given signer
+func ReplaceStuckTransactionWithSigner(
+	signer signers.Signer,
+	rpcClient goSdkRPC.T,
+	chain *common.ChainID,
+	fromAddress string,
+	fromShardID uint32,
+	toAddress string,
+	toShardID uint32,
+	amount numeric.Dec,
+	gasLimit int64,
+	gasPrice numeric.Dec,
+	nonce uint64,
+	inputData string,
+	node string,
+	txHash string,
+	policy ReplacementPolicy,
+) (*Receipt, error) {
+	if signer == nil {
+		return nil, libErrors.ErrMissingAccount
+	}
+
+	txContext := NewLegacyTxContext(signer, rpcClient, chain, gasLimit, gasPrice, nonce, node, 0).WithFrom(fromAddress)
+
+	return ReplaceStuckTransactionWithTxContext(context.Background(), txContext, fromShardID, toAddress, toShardID, amount, inputData, txHash, policy)
+}
+
+// ReplaceStuckTransactionWithTxContext - same as ReplaceStuckTransaction using a given tx context, whose nonce has to be the nonce of the stuck tx
+func ReplaceStuckTransactionWithTxContext(
+	ctx context.Context,
+	txContext *TxContext,
+	fromShardID uint32,
+	toAddress string,
+	toShardID uint32,
+	amount numeric.Dec,
+	inputData string,
+	txHash string,
+	policy ReplacementPolicy,
+) (*Receipt, error) {
+	if err := txContext.Validate(); err != nil {
+		return nil, err
+	}
+
+	if txContext.Client == nil {
+		return nil, ErrMissingClient
+	}
+
+	resend := func(bumpedGasPrice numeric.Dec) (string, error) {
+		return txContext.Resend(ctx, signers.TypeTransaction, func(nonce uint64) (interface{}, error) {
+			return GenerateAndSignTransactionWithSigner(txContext.Signer, txContext.Chain, txContext.FromAddress(), fromShardID, toAddress, toShardID, amount, txContext.GasLimit, bumpedGasPrice, nonce, inputData)
+		})
+	}
+
+	receipt, err := ReplaceUntilConfirmed(ctx, txContext.Watcher(signers.TypeTransaction), policy, txHash, txContext.GasPrice, BumpGasPrice, resend)
+	if receipt != nil {
+		receipt.ToShardID = toShardID
+	}
+
+	return receipt, err
+}
+
+// ReplaceUntilConfirmed - waits for txHash to be confirmed and calls resend with a bumped gas price every time none of the sent txs got confirmed within policy.Timeout
+// resend has to sign and send the replacement tx (using the same nonce) and return its hash
+// Returns the receipt for whichever of the sent txs got confirmed or ErrConfirmationTimeout once the policy is exhausted
+func ReplaceUntilConfirmed(
+	ctx context.Context,
+	watcher *ConfirmationWatcher,
+	policy ReplacementPolicy,
+	txHash string,
+	gasPrice numeric.Dec,
+	bumpGasPrice func(numeric.Dec) numeric.Dec,
+	resend func(gasPrice numeric.Dec) (string, error),
+) (*Receipt, error) {
+	if bumpGasPrice == nil {
+		bumpGasPrice = BumpGasPrice
+	}
+
+	timeout := policy.Timeout
+	if timeout <= 0 {
+		timeout = DefaultReplacementTimeout
+	}
+
+	pending := []string{txHash}
+	var lastErr error
+
+	for attempt := 0; ; attempt++ {
+		roundCtx, cancel := context.WithTimeout(ctx, timeout)
+		results := watcher.WaitAll(roundCtx, pending)
+		cancel()
+
+		stillPending := []string{}
+		for _, hash := range pending {
+			result := results[hash]
+			switch {
+			case result.Receipt != nil:
+				return result.Receipt, nil
+			case result.Error == nil || errors.Is(result.Error, libErrors.ErrConfirmationTimeout) || errors.Is(result.Error, context.Canceled):
+				stillPending = append(stillPending, hash)
+			default:
+				lastErr = result.Error
+			}
+		}
+		pending = stillPending
+
+		if err := ctx.Err(); err != nil {
+			if errors.Is(err, context.DeadlineExceeded) {
+				return nil, libErrors.ErrConfirmationTimeout
+			}
+			return nil, err
+		}
+
+		if len(pending) == 0 {
+			return nil, lastErr
+		}
+
+		if attempt >= policy.MaxAttempts {
+			return nil, libErrors.ErrConfirmationTimeout
+		}
+
+		bumpedGasPrice := bumpGasPrice(gasPrice)
+		if !policy.MaxGasPrice.IsNil() && bumpedGasPrice.GT(policy.MaxGasPrice) {
+			return nil, libErrors.ErrConfirmationTimeout
+		}
+
+		replacementHash, err := resend(bumpedGasPrice)
+		if err != nil {
+			return nil, err
+		}
+
+		logging.Resolve(watcher.Logger, logging.FromContext(ctx)).Log(logging.InfoLevel, "replaced stuck transaction",
+			logging.F("replaced-tx-hash", pending[len(pending)-1]),
+			logging.TxHash(replacementHash),
+			logging.F("gas-price", gasPrice),
+			logging.F("bumped-gas-price", bumpedGasPrice),
+		)
+
+		gasPrice = bumpedGasPrice
+		pending = append(pending, replacementHash)
+	}
+}
@@ -0,0 +1,166 @@
+package transactions_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	ethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	libErrors "github.com/harmony-one/go-lib/errors"
+	"github.com/harmony-one/go-lib/rpc"
+	"github.com/harmony-one/go-lib/rpc/mock"
+	"github.com/harmony-one/go-lib/signers"
+	"github.com/harmony-one/go-lib/transactions"
+	"github.com/harmony-one/go-sdk/pkg/address"
+	"github.com/harmony-one/go-sdk/pkg/common"
+	"github.com/harmony-one/harmony/numeric"
+)
+
+var receiverAddress = address.ToBech32(ethCommon.HexToAddress("0x2222222222222222222222222222222222222222"))
+
+type replacementResult struct {
+	receipt *transactions.Receipt
+	err     error
+}
+
+// sendStuckTransaction - sends a tx with nonce 0 and a gas price of 1 Gwei to a node that doesn't confirm it and returns its hash
+func sendStuckTransaction(t *testing.T, node *mock.Node) (*transactions.TxContext, string) {
+	t.Helper()
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("failed to generate a key: %v", err)
+	}
+
+	txContext := transactions.NewTxContext(signers.NewECDSAKeySigner(key), rpc.NewClientWithRetry(node.URL, nil), &common.Chain.TestNet).
+		WithGas(21000, numeric.NewDec(1)).
+		WithNonce(0).
+		WithWaitPolicy(transactions.WaitPolicy{PollInterval: 5 * time.Millisecond})
+
+	txHash, err := txContext.Resend(context.Background(), signers.TypeTransaction, func(nonce uint64) (interface{}, error) {
+		return transactions.GenerateAndSignTransactionWithSigner(txContext.Signer, txContext.Chain, txContext.FromAddress(), 0, receiverAddress, 0, numeric.NewDec(1), txContext.GasLimit, txContext.GasPrice, nonce, "")
+	})
+	if err != nil {
+		t.Fatalf("failed to send the transaction: %v", err)
+	}
+
+	return txContext, txHash
+}
+
+func replaceStuckTransaction(txContext *transactions.TxContext, txHash string, policy transactions.ReplacementPolicy) <-chan replacementResult {
+	results := make(chan replacementResult, 1)
+	go func() {
+		receipt, err := transactions.ReplaceStuckTransactionWithTxContext(context.Background(), txContext, 0, receiverAddress, 0, numeric.NewDec(1), "", txHash, policy)
+		results <- replacementResult{receipt: receipt, err: err}
+	}()
+
+	return results
+}
+
+func waitForResult(t *testing.T, results <-chan replacementResult) replacementResult {
+	t.Helper()
+
+	select {
+	case result := <-results:
+		return result
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the replacement to finish")
+	}
+
+	return replacementResult{}
+}
+
+// expectGasPrices - checks the gas prices (in Gwei) of the txs sent to the node
+func expectGasPrices(t *testing.T, node *mock.Node, gasPrices ...numeric.Dec) {
+	t.Helper()
+
+	sent := node.SentTransactions()
+	if len(sent) != len(gasPrices) {
+		t.Fatalf("expected %d sent txs, got %d", len(gasPrices), len(sent))
+	}
+
+	for index, tx := range sent {
+		if tx.Nonce != 0 {
+			t.Errorf("expected tx %d to reuse nonce 0, got %d", index, tx.Nonce)
+		}
+
+		if expected := gasPrices[index].Mul(transactions.NanoAsDec).TruncateInt(); tx.GasPrice.Cmp(expected) != 0 {
+			t.Errorf("expected tx %d to have a gas price of %s wei, got %s wei", index, expected, tx.GasPrice)
+		}
+	}
+}
+
+func TestReplaceStuckTransactionConfirmsOriginal(t *testing.T) {
+	node := mock.NewNode()
+	defer node.Close()
+
+	txContext, txHash := sendStuckTransaction(t, node)
+
+	results := replaceStuckTransaction(txContext, txHash, transactions.ReplacementPolicy{Timeout: 200 * time.Millisecond, MaxAttempts: 3})
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(node.SentTransactions()) < 2 {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for the replacement tx")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	// The original tx gets included after all, the replacement has to be considered done
+	node.SetReceipt(mock.Receipt{TransactionHash: txHash, BlockNumber: 1, Status: 1})
+
+	result := waitForResult(t, results)
+	if result.err != nil {
+		t.Fatalf("unexpected error: %v", result.err)
+	}
+
+	if result.receipt == nil || result.receipt.TransactionHash != txHash {
+		t.Errorf("expected the receipt of the original tx %s, got %+v", txHash, result.receipt)
+	}
+
+	expectGasPrices(t, node, numeric.NewDec(1), transactions.BumpGasPrice(numeric.NewDec(1)))
+}
+
+func TestReplaceStuckTransactionStopsAtCeiling(t *testing.T) {
+	firstBump := transactions.BumpGasPrice(numeric.NewDec(1))
+	secondBump := transactions.BumpGasPrice(firstBump)
+
+	testCases := []struct {
+		name      string
+		policy    transactions.ReplacementPolicy
+		gasPrices []numeric.Dec
+	}{
+		{
+			name:      "max attempts",
+			policy:    transactions.ReplacementPolicy{Timeout: 20 * time.Millisecond, MaxAttempts: 2},
+			gasPrices: []numeric.Dec{numeric.NewDec(1), firstBump, secondBump},
+		},
+		{
+			name:      "max gas price",
+			policy:    transactions.ReplacementPolicy{Timeout: 20 * time.Millisecond, MaxAttempts: 5, MaxGasPrice: firstBump},
+			gasPrices: []numeric.Dec{numeric.NewDec(1), firstBump},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			node := mock.NewNode()
+			defer node.Close()
+
+			txContext, txHash := sendStuckTransaction(t, node)
+
+			result := waitForResult(t, replaceStuckTransaction(txContext, txHash, testCase.policy))
+			if !errors.Is(result.err, libErrors.ErrConfirmationTimeout) {
+				t.Fatalf("expected ErrConfirmationTimeout, got %v", result.err)
+			}
+
+			if result.receipt != nil {
+				t.Errorf("expected no receipt, got %+v", result.receipt)
+			}
+
+			expectGasPrices(t, node, testCase.gasPrices...)
+		})
+	}
+}
@@ -2,14 +2,14 @@ package transactions
 
 import (
 	"bytes"
+	"context"
 	"encoding/base64"
+	"encoding/json"
 	"errors"
-	"fmt"
 	"time"
 
 	eth_hexutil "github.com/ethereum/go-ethereum/common/hexutil"
 	eth_rlp "github.com/ethereum/go-ethereum/rlp"
-	"github.com/harmony-one/go-lib/network"
 	"github.com/harmony-one/go-lib/rpc"
 	goSdkRPC "github.com/harmony-one/go-sdk/pkg/rpc"
 	"github.com/harmony-one/harmony/common/denominations"
@@ -47,31 +47,27 @@ type Transaction struct {
 	Timeout         int
 	TransactionHash string
 	Success         bool
-	Response        map[string]interface{}
+	Receipt         *Receipt
 	Error           error
 }
 
-// ToTransaction - converts a raw tx response map to a typed Transaction type
-func ToTransaction(fromAddress string, fromShardID uint32, toAddress string, toShardID uint32, rawTx map[string]interface{}, err error) Transaction {
+// ToTransaction - converts a tx receipt to a typed Transaction type
+func ToTransaction(fromAddress string, fromShardID uint32, toAddress string, toShardID uint32, receipt *Receipt, err error) Transaction {
 	if err != nil {
 		return Transaction{Error: err}
 	}
 
 	var tx Transaction
 
-	txHash := rawTx["transactionHash"].(string)
-
-	if txHash != "" {
-		success := IsTransactionSuccessful(rawTx)
-
+	if receipt != nil && receipt.TransactionHash != "" {
 		tx = Transaction{
 			FromAddress:     fromAddress,
 			FromShardID:     fromShardID,
 			ToAddress:       toAddress,
 			ToShardID:       toShardID,
-			TransactionHash: txHash,
-			Success:         success,
-			Response:        rawTx,
+			TransactionHash: receipt.TransactionHash,
+			Success:         IsTransactionSuccessful(receipt),
+			Receipt:         receipt,
 		}
 	}
 
@@ -91,63 +87,41 @@ func EncodeSignature(tx interface{}) (*string, error) {
 	return signature, nil
 }
 
-// SendRawTransaction - sends a raw signed transaction via RPC
-func SendRawTransaction(rpcClient *goSdkRPC.HTTPMessenger, signature *string) (interface{}, error) {
+// SendRawTransaction - sends a raw signed transaction via RPC and returns the transaction hash
+func SendRawTransaction(rpcClient goSdkRPC.T, signature *string) (string, error) {
 	reply, err := rpcClient.SendRPC(goSdkRPC.Method.SendRawTransaction, []interface{}{signature})
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
-	receiptHash, _ := reply["result"]
+	return ParseTransactionHash(reply)
+}
+
+// ParseTransactionHash - extracts the transaction hash from a SendRawTransaction / SendRawStakingTransaction reply
+func ParseTransactionHash(reply goSdkRPC.Reply) (string, error) {
+	receiptHash, ok := reply["result"].(string)
+	if !ok || receiptHash == "" {
+		return "", errors.New("transaction hash missing from response")
+	}
 
 	return receiptHash, nil
 }
 
 // WaitForTxConfirmation - waits a given amount of seconds defined by timeout to try to receive a finalized transaction
-func WaitForTxConfirmation(rpcClient *goSdkRPC.HTTPMessenger, node string, txType string, receiptHash string, timeout int) (map[string]interface{}, error) {
-	var failures []rpc.Failure
-
-	if timeout > 0 {
-		for {
-			if timeout < 0 {
-				return nil, nil
-			}
-
-			if txType == "transaction" {
-				failures, _ = rpc.TransactionFailures(node)
-				if err := handleTransactionError(receiptHash, failures); err != nil {
-					if network.Verbose {
-						fmt.Println(fmt.Sprintf("\n[Harmony SDK]: %s - transaction error occurred for tx %s, error: %s", time.Now().Format(network.LoggingTimeFormat), receiptHash, err.Error()))
-						fmt.Println("")
-					}
-					return nil, err
-				}
-			} else if txType == "staking" {
-				failures, _ = rpc.StakingFailures(node)
-				if err := handleTransactionError(receiptHash, failures); err != nil {
-					if network.Verbose {
-						fmt.Println(fmt.Sprintf("\n[Harmony SDK]: %s - staking error occurred for tx %s, error: %s", time.Now().Format(network.LoggingTimeFormat), receiptHash, err.Error()))
-						fmt.Println("")
-					}
-					return nil, err
-				}
-			}
-
-			response, err := GetTransactionReceipt(rpcClient, receiptHash)
-			if err != nil {
-				return nil, err
-			}
+// The receipt is fetched using rpcClient and the error sinks using node, a timeout <= 0 returns immediately without waiting (nil, nil)
+// Returns ErrConfirmationTimeout if the transaction wasn't confirmed within the timeout, use a ConfirmationWatcher for more control
+func WaitForTxConfirmation(rpcClient goSdkRPC.T, node string, txType string, receiptHash string, timeout int) (*Receipt, error) {
+	if timeout <= 0 {
+		return nil, nil
+	}
 
-			if response != nil {
-				return response, nil
-			}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
+	defer cancel()
 
-			time.Sleep(time.Second * 1)
-			timeout = timeout - 1
-		}
-	}
+	watcher := NewConfirmationWatcher(rpc.NewClient(node), txType)
+	watcher.Messenger = rpcClient
 
-	return nil, nil
+	return watcher.Wait(ctx, receiptHash)
 }
 
 func handleTransactionError(receiptHash string, failures []rpc.Failure) error {
@@ -193,29 +167,37 @@ func BumpGasPrice(gasPrice numeric.Dec) numeric.Dec {
 	return gasPrice.Mul(numeric.NewDec(100 + int64(core.DefaultTxPoolConfig.PriceBump)).Quo(numeric.NewDec(100)))
 }
 
-// GetTransactionReceipt - retrieves the transaction info/data for a transaction
-func GetTransactionReceipt(rpcClient *goSdkRPC.HTTPMessenger, receiptHash interface{}) (map[string]interface{}, error) {
+// GetTransactionReceipt - retrieves the receipt for a transaction, returns nil if the transaction hasn't been confirmed yet
+func GetTransactionReceipt(rpcClient goSdkRPC.T, receiptHash string) (*Receipt, error) {
 	response, err := rpcClient.SendRPC(goSdkRPC.Method.GetTransactionReceipt, []interface{}{receiptHash})
 	if err != nil {
 		return nil, err
 	}
 
-	if response["result"] != nil {
-		return response["result"].(map[string]interface{}), nil
+	if response["result"] == nil {
+		return nil, nil
 	}
 
-	return nil, nil
-}
+	bytes, err := json.Marshal(response["result"])
+	if err != nil {
+		return nil, err
+	}
 
-// IsTransactionSuccessful - checks if a transaction is successful given a transaction response
-func IsTransactionSuccessful(txResponse map[string]interface{}) (success bool) {
-	txStatus, ok := txResponse["status"].(string)
+	receipt := Receipt{}
+	if err := json.Unmarshal(bytes, &receipt); err != nil {
+		return nil, err
+	}
 
-	if txStatus != "" && ok {
-		success = (txStatus == "0x1")
+	if err := receipt.Initialize(); err != nil {
+		return nil, err
 	}
 
-	return success
+	return &receipt, nil
+}
+
+// IsTransactionSuccessful - checks if a transaction is successful given a transaction receipt
+func IsTransactionSuccessful(receipt *Receipt) bool {
+	return receipt != nil && receipt.IsSuccessful()
 }
 
 // GenerateTxData - generates tx data based on a given byte size
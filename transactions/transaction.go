@@ -1,12 +1,12 @@
 package transactions
 
 import (
-	"fmt"
+	"context"
 	"math/big"
-	"time"
 
 	libErrors "github.com/harmony-one/go-lib/errors"
-	"github.com/harmony-one/go-lib/network"
+	"github.com/harmony-one/go-lib/logging"
+	"github.com/harmony-one/go-lib/signers"
 	"github.com/harmony-one/go-sdk/pkg/address"
 	"github.com/harmony-one/go-sdk/pkg/common"
 	goSdkRPC "github.com/harmony-one/go-sdk/pkg/rpc"
@@ -18,62 +18,52 @@ import (
 )
 
 // SendTransaction - send transactions
-func SendTransaction(keystore *keystore.KeyStore, account *accounts.Account, rpcClient *goSdkRPC.HTTPMessenger, chain *common.ChainID, fromAddress string, fromShardID uint32, toAddress string, toShardID uint32, amount numeric.Dec, gasLimit int64, gasPrice numeric.Dec, nonce uint64, inputData string, keystorePassphrase string, node string, timeout int) (map[string]interface{}, error) {
+func SendTransaction(keystore *keystore.KeyStore, account *accounts.Account, rpcClient goSdkRPC.T, chain *common.ChainID, fromAddress string, fromShardID uint32, toAddress string, toShardID uint32, amount numeric.Dec, gasLimit int64, gasPrice numeric.Dec, nonce uint64, inputData string, keystorePassphrase string, node string, timeout int) (*Receipt, error) {
 	if keystore == nil || account == nil {
 		return nil, libErrors.ErrMissingAccount
 	}
 
-	signedTx, err := GenerateAndSignTransaction(
-		keystore,
-		account,
-		chain,
-		fromAddress,
-		fromShardID,
-		toAddress,
-		toShardID,
-		amount,
-		gasLimit,
-		gasPrice,
-		nonce,
-		inputData,
-	)
-	if err != nil {
-		return nil, err
-	}
+	return SendTransactionWithSigner(signers.NewKeystoreSigner(keystore, account), rpcClient, chain, fromAddress, fromShardID, toAddress, toShardID, amount, gasLimit, gasPrice, nonce, inputData, node, timeout)
+}
 
-	signature, err := EncodeSignature(signedTx)
-	if err != nil {
-		return nil, err
+// SendTransactionWithSigner - send transactions using a given signer
+func SendTransactionWithSigner(signer signers.Signer, rpcClient goSdkRPC.T, chain *common.ChainID, fromAddress string, fromShardID uint32, toAddress string, toShardID uint32, amount numeric.Dec, gasLimit int64, gasPrice numeric.Dec, nonce uint64, inputData string, node string, timeout int) (*Receipt, error) {
+	if signer == nil {
+		return nil, libErrors.ErrMissingAccount
 	}
 
-	if network.Verbose {
-		fmt.Printf("\n[Harmony SDK]: %s - signed transaction using chain: %s (id: %d), signature: %v\n", time.Now().Format(network.LoggingTimeFormat), chain.Name, chain.Value, signature)
-		json, _ := signedTx.MarshalJSON()
-		fmt.Printf("%s\n", common.JSONPrettyFormat(string(json)))
-		fmt.Printf("\n[Harmony SDK]: %s - sending transaction using node: %s, chain: %s (id: %d), signature: %v, timeout: %d\n\n", time.Now().Format(network.LoggingTimeFormat), node, chain.Name, chain.Value, signature, timeout)
-	}
+	txContext := NewLegacyTxContext(signer, rpcClient, chain, gasLimit, gasPrice, nonce, node, timeout).WithFrom(fromAddress)
 
-	receiptHash, err := SendRawTransaction(rpcClient, signature)
-	if err != nil {
-		return nil, err
-	}
+	return SendTransactionWithTxContext(context.Background(), txContext, fromShardID, toAddress, toShardID, amount, inputData)
+}
 
-	if timeout > 0 {
-		hash := receiptHash.(string)
-		result, err := WaitForTxConfirmation(rpcClient, node, "transaction", hash, timeout)
+// SendTransactionWithTxContext - send transactions using the signer, client, chain, gas, nonce and wait policy of a given tx context
+func SendTransactionWithTxContext(ctx context.Context, txContext *TxContext, fromShardID uint32, toAddress string, toShardID uint32, amount numeric.Dec, inputData string) (*Receipt, error) {
+	return txContext.Send(ctx, signers.TypeTransaction, fromShardID, toShardID, func(nonce uint64) (interface{}, error) {
+		signedTx, err := GenerateAndSignTransactionWithSigner(
+			txContext.Signer,
+			txContext.Chain,
+			txContext.FromAddress(),
+			fromShardID,
+			toAddress,
+			toShardID,
+			amount,
+			txContext.GasLimit,
+			txContext.GasPrice,
+			nonce,
+			inputData,
+		)
 		if err != nil {
 			return nil, err
 		}
 
-		if result != nil {
-			return result, nil
+		if logger := txContext.logger(ctx); logger.Enabled(logging.DebugLevel) {
+			json, _ := signedTx.MarshalJSON()
+			logger.Log(logging.DebugLevel, "generated transaction", logging.F("transaction", common.JSONPrettyFormat(string(json))))
 		}
-	}
 
-	result := make(map[string]interface{})
-	result["transactionHash"] = receiptHash
-
-	return result, nil
+		return signedTx, nil
+	})
 }
 
 // GenerateAndSignTransaction - generates and signs a transaction based on the supplied tx params and keystore/account
@@ -90,13 +80,30 @@ func GenerateAndSignTransaction(
 	gasPrice numeric.Dec,
 	nonce uint64,
 	inputData string,
+) (tx *types.Transaction, err error) {
+	return GenerateAndSignTransactionWithSigner(signers.NewKeystoreSigner(keystore, account), chain, fromAddress, fromShardID, toAddress, toShardID, amount, gasLimit, gasPrice, nonce, inputData)
+}
+
+// GenerateAndSignTransactionWithSigner - generates and signs a transaction based on the supplied tx params and signer
+func GenerateAndSignTransactionWithSigner(
+	signer signers.Signer,
+	chain *common.ChainID,
+	fromAddress string,
+	fromShardID uint32,
+	toAddress string,
+	toShardID uint32,
+	amount numeric.Dec,
+	gasLimit int64,
+	gasPrice numeric.Dec,
+	nonce uint64,
+	inputData string,
 ) (tx *types.Transaction, err error) {
 	generatedTx, err := GenerateTransaction(fromAddress, fromShardID, toAddress, toShardID, amount, gasLimit, gasPrice, nonce, inputData)
 	if err != nil {
 		return nil, err
 	}
 
-	tx, err = SignTransaction(keystore, account, generatedTx, chain.Value)
+	tx, err = signer.SignTx(generatedTx, chain.Value)
 	if err != nil {
 		return nil, err
 	}
@@ -121,19 +128,16 @@ func GenerateTransaction(
 		return nil, err
 	}
 
-	if network.Verbose {
-		fmt.Println(fmt.Sprintf("\n[Harmony SDK]: %s - Generating a new transaction:\n\tReceiver address: %s\n\tFrom shard: %d\n\tTo shard: %d\n\tAmount: %f\n\tNonce: %d\n\tGas limit: %d\n\tGas price: %f\n\tData length (bytes): %d\n",
-			time.Now().Format(network.LoggingTimeFormat),
-			toAddress,
-			fromShardID,
-			toShardID,
-			amount,
-			nonce,
-			calculatedGasLimit,
-			gasPrice,
-			len(inputData)),
-		)
-	}
+	logging.Debug(nil, "generating a new transaction",
+		logging.F("receiver", toAddress),
+		logging.ShardID(fromShardID),
+		logging.ToShardID(toShardID),
+		logging.F("amount", amount),
+		logging.Nonce(nonce),
+		logging.F("gas-limit", calculatedGasLimit),
+		logging.F("gas-price", gasPrice),
+		logging.F("data-length", len(inputData)),
+	)
 
 	tx = transaction.NewTransaction(
 		nonce,
@@ -0,0 +1,443 @@
+package transactions
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	libErrors "github.com/harmony-one/go-lib/errors"
+	"github.com/harmony-one/go-lib/logging"
+	"github.com/harmony-one/go-lib/network/rpc/nonces"
+	"github.com/harmony-one/go-lib/rpc"
+	"github.com/harmony-one/go-lib/signers"
+	"github.com/harmony-one/go-sdk/pkg/common"
+	goSdkRPC "github.com/harmony-one/go-sdk/pkg/rpc"
+	"github.com/harmony-one/harmony/numeric"
+)
+
+var (
+	// ErrMissingClient is returned if a TxContext has neither a Client nor a Messenger to send txs with
+	ErrMissingClient = errors.New("tx context requires a client to send transactions")
+
+	// ErrMissingChain is returned if a TxContext has no chain id to sign txs for
+	ErrMissingChain = errors.New("tx context requires a chain id to sign transactions")
+)
+
+// NonceSource - hands out nonces for the txs sent using a TxContext, implemented by nonces.NonceManager
+type NonceSource interface {
+	Reserve(ctx context.Context, address string, shardID uint32) (uint64, error)
+	Release(address string, shardID uint32, nonce uint64)
+}
+
+// NonceErrorHandler - optionally implemented by a NonceSource to recover from errors caused by a stale nonce (e.g. by resyncing), implemented by nonces.NonceManager
+type NonceErrorHandler interface {
+	HandleError(ctx context.Context, address string, shardID uint32, err error) (resynced bool, resyncErr error)
+}
+
+// WaitPolicy - controls if and how long to wait for a sent tx to be confirmed
+type WaitPolicy struct {
+	Timeout       time.Duration // Timeout - maximum time to wait for the tx to be confirmed, 0 means the tx isn't waited for
+	PollInterval  time.Duration // PollInterval - defaults to the ConfirmationWatcher default
+	Confirmations uint64        // Confirmations - number of blocks that have to be built on top of the tx block
+}
+
+// TxContext - bundles everything the send paths need besides the tx payload: signer, client, chain, gas, nonce and wait policy
+// The With* methods return modified copies, so a shared base context can be used to derive the context for every tx
+type TxContext struct {
+	Signer      signers.Signer
+	From        string      // From - sender address, defaults to the signer's address
+	Client      *rpc.Client // Client - used to send the txs and to wait for their confirmation
+	Messenger   goSdkRPC.T  // Messenger - if set, txs are sent and receipts are fetched using the messenger instead of Client (used by the legacy functions)
+	Chain       *common.ChainID
+	GasLimit    int64 // GasLimit - -1 calculates the gas limit based on the tx data
+	GasPrice    numeric.Dec
+	Nonce       uint64      // Nonce - used unless NonceSource is set
+	NonceSource NonceSource // NonceSource - if set, a nonce is reserved for every tx and released again if the tx never reached the tx pool
+	Wait        WaitPolicy
+	Logger      logging.Logger // Logger - overrides the logger of the context / the global logger
+
+	SkipPreflight    bool // SkipPreflight - skips the client-side validation of staking directives before signing, e.g. to test how nodes handle invalid txs
+	DryRun           bool // DryRun - generates and signs the txs without sending them, the receipts only carry a DryRunResult
+	IgnoreWaitErrors bool // IgnoreWaitErrors - failed confirmations (error sink entries, receipt lookup errors) are logged and the receipt only contains the tx hash, used by the legacy staking functions
+}
+
+// NewTxContext - creates a new tx context for a given signer, client and chain using an automatically calculated gas limit and a gas price of 1
+func NewTxContext(signer signers.Signer, client *rpc.Client, chain *common.ChainID) *TxContext {
+	return &TxContext{
+		Signer:   signer,
+		Client:   client,
+		Chain:    chain,
+		GasLimit: -1,
+		GasPrice: numeric.NewDec(1),
+	}
+}
+
+// WithSigner - returns a copy of the tx context using a given signer
+func (txContext *TxContext) WithSigner(signer signers.Signer) *TxContext {
+	copied := *txContext
+	copied.Signer = signer
+	return &copied
+}
+
+// WithFrom - returns a copy of the tx context using a given sender address
+func (txContext *TxContext) WithFrom(from string) *TxContext {
+	copied := *txContext
+	copied.From = from
+	return &copied
+}
+
+// WithClient - returns a copy of the tx context using a given client
+func (txContext *TxContext) WithClient(client *rpc.Client) *TxContext {
+	copied := *txContext
+	copied.Client = client
+	return &copied
+}
+
+// WithGas - returns a copy of the tx context using a given gas limit and gas price
+func (txContext *TxContext) WithGas(gasLimit int64, gasPrice numeric.Dec) *TxContext {
+	copied := *txContext
+	copied.GasLimit = gasLimit
+	copied.GasPrice = gasPrice
+	return &copied
+}
+
+// WithNonce - returns a copy of the tx context using a fixed nonce
+func (txContext *TxContext) WithNonce(nonce uint64) *TxContext {
+	copied := *txContext
+	copied.Nonce = nonce
+	copied.NonceSource = nil
+	return &copied
+}
+
+// WithNonceSource - returns a copy of the tx context reserving the nonces from a given source
+func (txContext *TxContext) WithNonceSource(source NonceSource) *TxContext {
+	copied := *txContext
+	copied.NonceSource = source
+	return &copied
+}
+
+// WithWait - returns a copy of the tx context waiting up to timeout for the txs to be confirmed
+func (txContext *TxContext) WithWait(timeout time.Duration) *TxContext {
+	copied := *txContext
+	copied.Wait.Timeout = timeout
+	return &copied
+}
+
+// WithWaitPolicy - returns a copy of the tx context using a given wait policy
+func (txContext *TxContext) WithWaitPolicy(policy WaitPolicy) *TxContext {
+	copied := *txContext
+	copied.Wait = policy
+	return &copied
+}
+
+// WithLogger - returns a copy of the tx context using a given logger
+func (txContext *TxContext) WithLogger(logger logging.Logger) *TxContext {
+	copied := *txContext
+	copied.Logger = logger
+	return &copied
+}
+
+// WithPreflight - returns a copy of the tx context with the client-side validation of staking directives enabled / disabled
+func (txContext *TxContext) WithPreflight(enabled bool) *TxContext {
+	copied := *txContext
+	copied.SkipPreflight = !enabled
+	return &copied
+}
+
+// WithDryRun - returns a copy of the tx context with dry-run mode enabled / disabled
+func (txContext *TxContext) WithDryRun(enabled bool) *TxContext {
+	copied := *txContext
+	copied.DryRun = enabled
+	return &copied
+}
+
+// WithIgnoreWaitErrors - returns a copy of the tx context that returns the tx hash instead of an error if waiting for the confirmation fails
+func (txContext *TxContext) WithIgnoreWaitErrors(ignore bool) *TxContext {
+	copied := *txContext
+	copied.IgnoreWaitErrors = ignore
+	return &copied
+}
+
+// FromAddress - returns the sender address
+func (txContext *TxContext) FromAddress() string {
+	if txContext.From != "" || txContext.Signer == nil {
+		return txContext.From
+	}
+
+	return txContext.Signer.Address()
+}
+
+// Validate - checks that the tx context contains everything required to sign and send txs, a client isn't required in dry-run mode
+func (txContext *TxContext) Validate() error {
+	if txContext.Signer == nil {
+		return libErrors.ErrMissingAccount
+	}
+
+	if txContext.Client == nil && txContext.Messenger == nil && !txContext.DryRun {
+		return ErrMissingClient
+	}
+
+	if txContext.Chain == nil {
+		return ErrMissingChain
+	}
+
+	return nil
+}
+
+// Send - reserves a nonce, signs the tx using sign, sends it and waits for it to be confirmed according to the wait policy
+// txType is one of signers.TypeTransaction, signers.TypeEthTransaction or signers.TypeStaking and determines the RPC method used
+// If the wait policy's timeout is reached the receipt only contains the tx hash
+// In dry-run mode the signed tx is never sent, a reserved nonce is released again and the receipt's DryRun field describes the tx
+func (txContext *TxContext) Send(ctx context.Context, txType string, fromShardID uint32, toShardID uint32, sign func(nonce uint64) (interface{}, error)) (*Receipt, error) {
+	if err := txContext.Validate(); err != nil {
+		return nil, err
+	}
+
+	logger := txContext.logger(ctx)
+	from := txContext.FromAddress()
+
+	nonce, err := txContext.reserveNonce(ctx, from, fromShardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if txContext.DryRun {
+		if txContext.NonceSource != nil {
+			defer txContext.NonceSource.Release(from, fromShardID, nonce)
+		}
+
+		result, err := txContext.dryRun(logger, nonce, fromShardID, toShardID, sign)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Receipt{TransactionHash: result.TransactionHash, From: from, ShardID: fromShardID, ToShardID: toShardID, DryRun: result}, nil
+	}
+
+	receiptHash, submitted, err := txContext.signAndSend(ctx, logger, txType, nonce, fromShardID, toShardID, sign)
+	if err != nil {
+		txContext.handleSendError(ctx, logger, from, fromShardID, nonce, submitted, err)
+		return nil, err
+	}
+
+	if txContext.Wait.Timeout > 0 && txContext.Client != nil {
+		receipt, err := txContext.waitForConfirmation(ctx, txType, receiptHash)
+		if err != nil && err != libErrors.ErrConfirmationTimeout {
+			// The error sinks report txs that were dropped from the pool, e.g. because their nonce was already used
+			txContext.handleNonceError(ctx, logger, from, fromShardID, nonce, err)
+			if !txContext.IgnoreWaitErrors {
+				return nil, err
+			}
+			logger.Log(logging.WarnLevel, "failed to wait for transaction confirmation", logging.TxHash(receiptHash), logging.ShardID(fromShardID), logging.Err(err))
+		}
+
+		if receipt != nil {
+			if txType != signers.TypeStaking {
+				receipt.ToShardID = toShardID
+			}
+			return receipt, nil
+		}
+	}
+
+	return &Receipt{TransactionHash: receiptHash, ShardID: fromShardID, ToShardID: toShardID}, nil
+}
+
+func (txContext *TxContext) dryRun(logger logging.Logger, nonce uint64, fromShardID uint32, toShardID uint32, sign func(nonce uint64) (interface{}, error)) (*DryRunResult, error) {
+	signedTx, err := sign(nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := EncodeSignature(signedTx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := NewDryRunResult(signedTx, *signature)
+	logger.Log(logging.InfoLevel, "dry run, not sending transaction", logging.TxHash(result.TransactionHash), logging.Nonce(nonce), logging.ShardID(fromShardID), logging.ToShardID(toShardID), logging.F("estimated-fee", result.EstimatedFee))
+
+	return result, nil
+}
+
+// signAndSend signs and sends a tx, submitted reports whether the tx was handed to the node (so it might have reached the tx pool)
+func (txContext *TxContext) signAndSend(ctx context.Context, logger logging.Logger, txType string, nonce uint64, fromShardID uint32, toShardID uint32, sign func(nonce uint64) (interface{}, error)) (receiptHash string, submitted bool, err error) {
+	signedTx, err := sign(nonce)
+	if err != nil {
+		return "", false, err
+	}
+
+	signature, err := EncodeSignature(signedTx)
+	if err != nil {
+		return "", false, err
+	}
+
+	description := "transaction"
+	if txType == signers.TypeStaking {
+		description = "staking transaction"
+	}
+
+	node := txContext.node()
+	if logger.Enabled(logging.DebugLevel) {
+		logger.Log(logging.DebugLevel, "signed "+description, logging.F("chain", txContext.Chain.Name), logging.F("chain-id", txContext.Chain.Value), logging.F("signature", *signature))
+		logger.Log(logging.DebugLevel, "sending "+description, logging.Node(node), logging.Nonce(nonce), logging.ShardID(fromShardID), logging.ToShardID(toShardID), logging.F("timeout", txContext.Wait.Timeout))
+	}
+
+	receiptHash, err = txContext.sendSignature(ctx, txType, signature)
+	if err != nil {
+		logger.Log(logging.ErrorLevel, "failed to send "+description, logging.Node(node), logging.Nonce(nonce), logging.ShardID(fromShardID), logging.ToShardID(toShardID), logging.Err(err))
+		return "", true, err
+	}
+
+	logger.Log(logging.InfoLevel, "sent "+description, logging.TxHash(receiptHash), logging.Node(node), logging.Nonce(nonce), logging.ShardID(fromShardID), logging.ToShardID(toShardID))
+
+	return receiptHash, true, nil
+}
+
+// handleSendError hands a reserved nonce back to the nonce source if the tx certainly never reached the tx pool
+// Stale nonces ("nonce too low") are passed to the nonce source's error handler instead, releasing them would hand them out again
+func (txContext *TxContext) handleSendError(ctx context.Context, logger logging.Logger, from string, shardID uint32, nonce uint64, submitted bool, err error) {
+	if txContext.NonceSource == nil {
+		return
+	}
+
+	if nonces.IsNonceTooLow(err) {
+		txContext.handleNonceError(ctx, logger, from, shardID, nonce, err)
+		return
+	}
+
+	if !submitted || isRejected(err) {
+		txContext.NonceSource.Release(from, shardID, nonce)
+	}
+}
+
+// handleNonceError passes a send / confirmation error to the nonce source's error handler (if it implements NonceErrorHandler) so that stale nonces get resynced
+func (txContext *TxContext) handleNonceError(ctx context.Context, logger logging.Logger, from string, shardID uint32, nonce uint64, err error) {
+	handler, ok := txContext.NonceSource.(NonceErrorHandler)
+	if !ok {
+		return
+	}
+
+	resynced, resyncErr := handler.HandleError(ctx, from, shardID, err)
+	if resyncErr != nil {
+		logger.Log(logging.WarnLevel, "failed to resync nonce", logging.Nonce(nonce), logging.ShardID(shardID), logging.Err(resyncErr))
+	} else if resynced {
+		logger.Log(logging.InfoLevel, "resynced nonce", logging.Nonce(nonce), logging.ShardID(shardID), logging.Err(err))
+	}
+}
+
+// sendSignature sends an encoded signed tx using the messenger or client and returns the tx hash
+func (txContext *TxContext) sendSignature(ctx context.Context, txType string, signature *string) (string, error) {
+	method := goSdkRPC.Method.SendRawTransaction
+	if txType == signers.TypeStaking {
+		method = goSdkRPC.Method.SendRawStakingTransaction
+	}
+
+	var reply goSdkRPC.Reply
+	var err error
+	if txContext.Messenger != nil {
+		reply, err = txContext.Messenger.SendRPC(method, []interface{}{signature})
+	} else {
+		reply, err = txContext.Client.Request(ctx, method, []interface{}{*signature})
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return ParseTransactionHash(reply)
+}
+
+// Resend - signs and sends a replacement for an already sent tx using the tx context's nonce, without waiting for it
+// In dry-run mode the replacement is only signed and its hash is returned
+func (txContext *TxContext) Resend(ctx context.Context, txType string, sign func(nonce uint64) (interface{}, error)) (string, error) {
+	signedTx, err := sign(txContext.Nonce)
+	if err != nil {
+		return "", err
+	}
+
+	signature, err := EncodeSignature(signedTx)
+	if err != nil {
+		return "", err
+	}
+
+	if txContext.DryRun {
+		return NewDryRunResult(signedTx, *signature).TransactionHash, nil
+	}
+
+	return txContext.sendSignature(ctx, txType, signature)
+}
+
+// Watcher - returns a confirmation watcher for a given tx type using the tx context's client / messenger and wait policy
+func (txContext *TxContext) Watcher(txType string) *ConfirmationWatcher {
+	watcherType := signers.TypeTransaction
+	if txType == signers.TypeStaking {
+		watcherType = signers.TypeStaking
+	}
+
+	watcher := NewConfirmationWatcher(txContext.Client, watcherType)
+	watcher.Messenger = txContext.Messenger
+	watcher.Confirmations = txContext.Wait.Confirmations
+	watcher.Logger = txContext.Logger
+	if txContext.Wait.PollInterval > 0 {
+		watcher.PollInterval = txContext.Wait.PollInterval
+	}
+
+	return watcher
+}
+
+func (txContext *TxContext) reserveNonce(ctx context.Context, from string, shardID uint32) (uint64, error) {
+	if txContext.NonceSource == nil {
+		return txContext.Nonce, nil
+	}
+
+	return txContext.NonceSource.Reserve(ctx, from, shardID)
+}
+
+func (txContext *TxContext) waitForConfirmation(ctx context.Context, txType string, receiptHash string) (*Receipt, error) {
+	waitCtx, cancel := context.WithTimeout(ctx, txContext.Wait.Timeout)
+	defer cancel()
+
+	return txContext.Watcher(txType).Wait(waitCtx, receiptHash)
+}
+
+// isRejected returns true if a node explicitly rejected a tx, i.e. the tx didn't enter the tx pool and its nonce is still unused
+// Transport errors are ambiguous (the tx might have been received before the connection failed) and "already known" means the tx is in the pool
+func isRejected(err error) bool {
+	var rpcError rpc.RPCError
+	if !errors.As(err, &rpcError) {
+		return false
+	}
+
+	return !strings.Contains(strings.ToLower(rpcError.Message), "already known")
+}
+
+func (txContext *TxContext) logger(ctx context.Context) logging.Logger {
+	return logging.Resolve(txContext.Logger, logging.FromContext(ctx))
+}
+
+func (txContext *TxContext) node() string {
+	if txContext.Client != nil {
+		return txContext.Client.Node
+	}
+
+	return ""
+}
+
+// NewLegacyTxContext - builds a tx context from the positional parameters of the legacy send functions
+// Txs are sent and their receipts are fetched using rpcClient while node is only used for the error sinks, txs are waited for up to timeout seconds
+// Pre-flight validation is skipped, so the legacy functions keep sending whatever payload they're given
+// Legacy staking functions additionally set IgnoreWaitErrors, they've always returned the tx hash even if the tx ended up in the error sink
+func NewLegacyTxContext(signer signers.Signer, rpcClient goSdkRPC.T, chain *common.ChainID, gasLimit int64, gasPrice numeric.Dec, nonce uint64, node string, timeout int) *TxContext {
+	return &TxContext{
+		Signer:    signer,
+		Client:    rpc.NewClient(node),
+		Messenger: rpcClient,
+		Chain:     chain,
+		GasLimit:  gasLimit,
+		GasPrice:  gasPrice,
+		Nonce:     nonce,
+		Wait:      WaitPolicy{Timeout: time.Duration(timeout) * time.Second},
+
+		SkipPreflight: true,
+	}
+}